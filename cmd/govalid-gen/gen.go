@@ -0,0 +1,570 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/harrysan/govalid/internal/tag"
+)
+
+// fieldType is the type of a field the generator can check without reflection:
+// a predeclared type or a slice of one
+type fieldType struct {
+	kind  reflect.Kind
+	slice bool
+}
+
+var predeclared = map[string]reflect.Kind{
+	"bool":    reflect.Bool,
+	"string":  reflect.String,
+	"int":     reflect.Int,
+	"int8":    reflect.Int8,
+	"int16":   reflect.Int16,
+	"int32":   reflect.Int32,
+	"rune":    reflect.Int32,
+	"int64":   reflect.Int64,
+	"uint":    reflect.Uint,
+	"uint8":   reflect.Uint8,
+	"byte":    reflect.Uint8,
+	"uint16":  reflect.Uint16,
+	"uint32":  reflect.Uint32,
+	"uint64":  reflect.Uint64,
+	"uintptr": reflect.Uintptr,
+	"float32": reflect.Float32,
+	"float64": reflect.Float64,
+}
+
+// validatorVar is the Validator of the generated code when the tags are
+// renamed with the -tag, -cond-tag and -message-tag flags. It is exported so
+// that custom rules and aliases can be registered on it
+const validatorVar = "GovalidValidator"
+
+type generator struct {
+	buf      bytes.Buffer
+	pkg      string
+	tagName  string
+	condTag  string
+	msgTag   string
+	declared map[string]bool
+	hooks    map[string]bool
+	imports  map[string]bool
+}
+
+func newGenerator(pkg, tagName, condTag, msgTag string) *generator {
+	return &generator{pkg: pkg, tagName: tagName, condTag: condTag, msgTag: msgTag, imports: map[string]bool{}}
+}
+
+// defaultTags reports whether the generator reads the tags of the default
+// Validator
+func (g *generator) defaultTags() bool {
+	return g.tagName == "validate" && g.condTag == "validate_if" && g.msgTag == "error_message"
+}
+
+// validator returns the expression of the Validator the reflective
+// fallbacks go through, one reading the same tags as the generator
+func (g *generator) validator() string {
+	if g.defaultTags() {
+		return "govalid"
+	}
+	return validatorVar
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// generate writes a Validate method for the named types, or for every struct
+// with validation tags when names is empty
+func (g *generator) generate(structs []structType, names []string) error {
+	g.declared = map[string]bool{}
+	byName := map[string]structType{}
+	for _, st := range structs {
+		g.declared[st.name] = true
+		byName[st.name] = st
+	}
+
+	if len(names) == 0 {
+		for _, st := range structs {
+			if g.hasTags(st.node) {
+				names = append(names, st.name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		st, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("struct type %s not found", name)
+		}
+		if err := g.generateStruct(st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) hasTags(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		tags := structTag(field)
+		if tags.Get(g.tagName) != "" || tags.Get(g.condTag) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) generateStruct(st structType) error {
+	g.imports[`govalid "github.com/harrysan/govalid/validator"`] = true
+
+	g.printf("// Validate validates %s like %s.ValidateStruct, without reflection\n", st.name, g.validator())
+	g.printf("func (x *%s) Validate() []govalid.ValidationError {\n", st.name)
+
+	if g.hooks[st.name] {
+		// the StructValidator hook needs the reflective walk
+		g.printf("return %s.ValidateStruct(x)\n}\n\n", g.validator())
+		return nil
+	}
+
+	g.printf("var errs []govalid.ValidationError\n")
+
+	declaredFields := map[string]bool{}
+	embedded := false
+	for _, field := range st.node.Fields.List {
+		if len(field.Names) == 0 {
+			embedded = true
+		}
+		for _, name := range field.Names {
+			declaredFields[name.Name] = true
+		}
+	}
+
+	for _, field := range st.node.Fields.List {
+		tags := structTag(field)
+		validate := tags.Get(g.tagName)
+		validateIf := tags.Get(g.condTag)
+		if validate == "" && validateIf == "" {
+			// untagged structs are still validated field by field
+			if g.mayNest(field.Type) {
+				for _, name := range namesOf(field) {
+					// embedded structs of unexported types hold promoted exported fields
+					if ast.IsExported(name) || len(field.Names) == 0 {
+						g.printf("\n// %s\nerrs = append(errs, %s.ValidatePartial(x, %q)...)\n", name, g.validator(), name)
+					}
+				}
+			}
+			continue
+		}
+
+		var cond *tag.Condition
+		if validateIf != "" {
+			c, err := tag.ParseCondition(validateIf)
+			if err != nil {
+				return fmt.Errorf("%s: invalid tag %q: %v", st.name, validateIf, err)
+			}
+			for _, and := range c.Or {
+				for _, comp := range and {
+					name, _, _ := strings.Cut(comp.Field, ".")
+					if !declaredFields[name] && !embedded {
+						return fmt.Errorf("%s: invalid tag %q: condition field '%s' not found", st.name, validateIf, comp.Field)
+					}
+				}
+			}
+			cond = &c
+		}
+
+		for _, name := range namesOf(field) {
+			if !ast.IsExported(name) && len(field.Names) > 0 {
+				continue
+			}
+
+			f := &fieldGen{
+				g:       g,
+				name:    name,
+				expr:    "x." + name,
+				message: tags.Get(g.msgTag),
+				imports: map[string]bool{},
+			}
+			g.printf("\n// %s `%s`\n", name, field.Tag.Value[1:len(field.Tag.Value)-1])
+
+			typ, ok := g.fieldType(field.Type)
+			if !ok || !f.generate(typ, validate, cond) {
+				g.printf("errs = append(errs, %s.ValidatePartial(x, %q)...)\n", g.validator(), name)
+			}
+		}
+	}
+
+	g.printf("\nreturn errs\n}\n\n")
+	return nil
+}
+
+// namesOf returns the names of a field, the type name for embedded fields
+func namesOf(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		switch t := expr.(type) {
+		case *ast.Ident:
+			return []string{t.Name}
+		case *ast.SelectorExpr:
+			return []string{t.Sel.Name}
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(field.Names))
+	for _, name := range field.Names {
+		names = append(names, name.Name)
+	}
+	return names
+}
+
+// mayNest reports whether a field type may hold a struct the reflective walk
+// descends into: named types, pointers to them and interfaces
+func (g *generator) mayNest(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return g.mayNest(t.X)
+	case *ast.Ident:
+		_, ok := predeclared[t.Name]
+		return !ok || g.declared[t.Name]
+	case *ast.SelectorExpr, *ast.InterfaceType:
+		return true
+	}
+	return false
+}
+
+// fieldType resolves the type of a field to a predeclared type or a slice of one
+func (g *generator) fieldType(expr ast.Expr) (fieldType, bool) {
+	slice := false
+	if arr, ok := expr.(*ast.ArrayType); ok && arr.Len == nil {
+		slice = true
+		expr = arr.Elt
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok || g.declared[ident.Name] {
+		return fieldType{}, false
+	}
+	kind, ok := predeclared[ident.Name]
+	return fieldType{kind: kind, slice: slice}, ok
+}
+
+func structTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	s, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(s)
+}
+
+// format returns the gofmt-ed source of the generated file
+func (g *generator) format() ([]byte, error) {
+	declare := !g.defaultTags() && g.imports[`govalid "github.com/harrysan/govalid/validator"`]
+	if declare {
+		g.imports[`"github.com/harrysan/govalid/rules"`] = true
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by govalid-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	for _, imp := range []string{`"errors"`, `"fmt"`, "", `"github.com/harrysan/govalid/rules"`, `govalid "github.com/harrysan/govalid/validator"`} {
+		if imp == "" || g.imports[imp] {
+			fmt.Fprintf(&out, "%s\n", imp)
+		}
+	}
+	fmt.Fprintf(&out, ")\n\n")
+	if declare {
+		fmt.Fprintf(&out, "// %s validates the fields without typed checks, reading the same tags and\n", validatorVar)
+		fmt.Fprintf(&out, "// the default regex rules. Register custom rules and aliases on it.\n")
+		fmt.Fprintf(&out, "var %s = govalid.New(govalid.WithRegexRules(rules.DefaultRegexRules()), govalid.WithTagName(%q), govalid.WithConditionTagName(%q), govalid.WithMessageTagName(%q))\n\n", validatorVar, g.tagName, g.condTag, g.msgTag)
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+// fieldGen writes the checks of a single field
+type fieldGen struct {
+	g       *generator
+	name    string
+	expr    string
+	message string
+	code    bytes.Buffer
+	imports map[string]bool
+}
+
+// generate writes the typed checks of a field, it returns false when a rule
+// has no typed equivalent and the field must use the reflective path
+func (f *fieldGen) generate(typ fieldType, validate string, cond *tag.Condition) bool {
+	// conditions compare typed values of the struct, the reflective path
+	// evaluates them
+	if cond != nil {
+		return false
+	}
+
+	var rules []tag.Rule
+	bail := false
+	if validate != "" {
+		parsed, err := tag.Parse(validate, ",")
+		if err != nil {
+			// the reflective path reports the TagSyntaxError
+			return false
+		}
+		for _, r := range parsed {
+			// negations and OR groups use the reflective path
+			if r.Not || len(r.Or) > 0 {
+				return false
+			}
+			if r.Name == "" {
+				continue
+			}
+			if r.Name == "bail" {
+				bail = true
+				continue
+			}
+			rules = append(rules, r)
+		}
+	}
+
+	if bail {
+		f.code.WriteString("{\nn := len(errs)\n")
+	}
+	for i, r := range rules {
+		if bail && i > 0 {
+			f.code.WriteString("if len(errs) == n {\n")
+		}
+		if !f.rule(typ, r, f.message) {
+			return false
+		}
+		if bail && i > 0 {
+			f.code.WriteString("}\n")
+		}
+	}
+	if bail {
+		f.code.WriteString("}\n")
+	}
+
+	f.g.buf.Write(f.code.Bytes())
+	for imp := range f.imports {
+		f.g.imports[imp] = true
+	}
+	return true
+}
+
+func (f *fieldGen) printf(format string, args ...any) {
+	fmt.Fprintf(&f.code, format, args...)
+}
+
+// report writes the statement appending a ValidationError, errExpr being
+// the expression of its error unless the field has an error_message
+func (f *fieldGen) report(r tag.Rule, errExpr, message string) {
+	if message != "" {
+		errExpr = fmt.Sprintf("errors.New(%q)", message)
+	}
+	f.imports[`"errors"`] = true
+	f.printf("errs = append(errs, govalid.ValidationError{Field: %q, StructField: %q, Tag: %q, Value: %s, Err: %s})\n", f.name, f.name, r.Tag, f.expr, errExpr)
+}
+
+// always writes a rule that fails for every value
+func (f *fieldGen) always(r tag.Rule, msg, message string) {
+	f.report(r, fmt.Sprintf("errors.New(%q)", msg), message)
+}
+
+// check writes a rule failing when cond holds
+func (f *fieldGen) check(r tag.Rule, cond, errExpr, message string) {
+	f.printf("if %s {\n", cond)
+	f.report(r, errExpr, message)
+	f.printf("}\n")
+}
+
+// each writes a rule checking every element of a slice, failing elements
+// are listed in the error like rules.ValidateRuleMin does
+func (f *fieldGen) each(r tag.Rule, kind reflect.Kind, cond, msg, message string) {
+	elem := "fmt.Sprint(e)"
+	if kind == reflect.String {
+		elem = "e"
+	} else {
+		f.imports[`"fmt"`] = true
+	}
+
+	f.printf("{\nmsg := \"\"\nfor _, e := range %s {\n", f.expr)
+	if cond != "true" {
+		f.printf("if %s {\n", cond)
+	}
+	f.printf("msg += \"(\" + %s + \")\" + %q\n", elem, msg+"; ")
+	if cond != "true" {
+		f.printf("}\n")
+	}
+	f.printf("}\n")
+	f.check(r, `msg != ""`, "errors.New(msg)", message)
+	f.printf("}\n")
+}
+
+// rule writes the typed check of a single rule, mirroring the rules package
+func (f *fieldGen) rule(typ fieldType, r tag.Rule, message string) bool {
+	kind := typ.kind
+
+	switch r.Name {
+	case "required":
+		switch {
+		case typ.slice:
+			f.check(r, fmt.Sprintf("len(%s) == 0", f.expr), `errors.New(" field is required;")`, message)
+		case kind == reflect.String:
+			f.check(r, f.expr+` == ""`, `errors.New(" field is required;")`, message)
+		case kind == reflect.Int:
+			f.check(r, f.expr+" == 0", `errors.New(" field is required;")`, message)
+		}
+		return true
+
+	case "min", "max":
+		num, _ := strconv.ParseFloat(r.Param, 64)
+		if typ.slice {
+			if cond, msg, ok := bound(r.Name, kind, "e", num); ok {
+				f.each(r, kind, cond, msg, message)
+			}
+		} else if cond, msg, ok := bound(r.Name, kind, f.expr, num); ok {
+			f.check(r, cond, fmt.Sprintf("errors.New(%q)", msg+"; "), message)
+		}
+		return true
+
+	case "len", "minItems", "maxItems":
+		n, _ := strconv.ParseFloat(r.Param, 64)
+		f.items(typ, r, int(n), message)
+		return true
+
+	case "email":
+		switch {
+		case typ.slice && kind == reflect.String:
+			f.imports[`"github.com/harrysan/govalid/rules"`] = true
+			f.each(r, kind, "!rules.MatchEmail(e)", " invalid email format", message)
+		case typ.slice:
+			f.each(r, kind, "true", "email validation only supports strings", message)
+		case kind == reflect.String:
+			f.imports[`"github.com/harrysan/govalid/rules"`] = true
+			f.check(r, "!rules.MatchEmail("+f.expr+")", `errors.New(" invalid email format; ")`, message)
+		default:
+			f.always(r, "email validation only supports strings; ", message)
+		}
+		return true
+
+	case "isTrue", "isFalse":
+		if kind == reflect.Bool && !typ.slice {
+			cond := f.expr
+			msg := "value must be false"
+			if r.Name == "isTrue" {
+				cond, msg = "!"+f.expr, "value must be true"
+			}
+			f.check(r, cond, fmt.Sprintf("errors.New(%q)", msg), message)
+		}
+		return true
+
+	case "slice":
+		if !typ.slice {
+			f.always(r, "value must be slice", message)
+		}
+		return true
+
+	case "maps":
+		f.always(r, "value must be map", message)
+		return true
+
+	case "struct":
+		f.always(r, " value must be struct", message)
+		return true
+
+	case "regex":
+		if typ.slice || kind != reflect.String || r.Quoted {
+			return false
+		}
+		f.imports[`"github.com/harrysan/govalid/rules"`] = true
+		f.printf("if re, err := rules.DefaultRegexRules().Regexp(%q); errors.Is(err, rules.ErrRegexRuleNotFound) {\n", r.Param)
+		f.report(r, fmt.Sprintf("errors.New(%q)", "regex rule "+r.Param+" not found for field "+f.name), message)
+		f.printf("} else if err != nil {\n")
+		f.report(r, fmt.Sprintf(`errors.New(" invalid regex pattern for " + %s + ";")`, f.expr), message)
+		f.printf("} else if !re.MatchString(%s) {\n", f.expr)
+		f.report(r, fmt.Sprintf(`errors.New(" " + %s + " does not match the required pattern;")`, f.expr), message)
+		f.printf("}\n")
+		return true
+	}
+
+	// custom rules, the eqfield family and unknown names are resolved at runtime
+	return false
+}
+
+// items writes a len, minItems or maxItems rule like rules.ValidateRuleLen,
+// rules.ValidateRuleMinItems and rules.ValidateRuleMaxItems
+func (f *fieldGen) items(typ fieldType, r tag.Rule, n int, message string) {
+	op, text := "!=", "exactly"
+	switch r.Name {
+	case "minItems":
+		op, text = "<", "at least"
+	case "maxItems":
+		op, text = ">", "at most"
+	}
+
+	switch {
+	case typ.slice:
+		f.imports[`"fmt"`] = true
+		cond := fmt.Sprintf("len(%s) %s %d", f.expr, op, n)
+		f.check(r, cond, fmt.Sprintf("fmt.Errorf(%q, len(%s))", fmt.Sprintf(" must contain %s %d items, got %%d", text, n), f.expr), message)
+	case r.Name == "len" && typ.kind == reflect.String:
+		f.check(r, fmt.Sprintf("len(%s) != %d", f.expr, n), fmt.Sprintf("errors.New(%q)", fmt.Sprintf(" must be exactly %d characters long", n)), message)
+	case r.Name == "len":
+		f.always(r, " len only supports strings, slices, arrays and maps", message)
+	default:
+		f.always(r, " "+r.Name+" only supports slices, arrays and maps", message)
+	}
+}
+
+// bound returns the condition and message of a min or max rule on a value
+// of the given kind, like rules.validateMin and rules.validateMax
+func bound(name string, kind reflect.Kind, expr string, num float64) (cond, msg string, ok bool) {
+	op, text := "<", " must be greater than or equal to "
+	if name == "max" {
+		op, text = ">", " must be less than or equal to "
+	}
+
+	switch kind {
+	case reflect.Int:
+		n := int(num)
+		return fmt.Sprintf("%s %s %d", expr, op, n), fmt.Sprintf("%s%d", text, n), true
+	case reflect.Int32:
+		n := int32(num)
+		return fmt.Sprintf("%s %s %d", expr, op, n), fmt.Sprintf("%s%d", text, n), true
+	case reflect.Int64:
+		n := int64(num)
+		return fmt.Sprintf("%s %s %d", expr, op, n), fmt.Sprintf("%s%d", text, n), true
+	case reflect.String:
+		n := int(num)
+		return fmt.Sprintf("len(%s) %s %d", expr, op, n), fmt.Sprintf("%s%d", text, n), true
+	case reflect.Float32:
+		n := float32(num)
+		lit := strconv.FormatFloat(float64(n), 'g', -1, 32)
+		if name == "max" {
+			return fmt.Sprintf("%s %s float32(%s)", expr, op, lit), fmt.Sprintf("%s%.1f", text, n), true
+		}
+		return fmt.Sprintf("%s %s float32(%s)", expr, op, lit), fmt.Sprintf("%s%f", text, n), true
+	case reflect.Float64:
+		lit := strconv.FormatFloat(num, 'g', -1, 64)
+		if name == "max" {
+			return fmt.Sprintf("%s %s %s", expr, op, lit), fmt.Sprintf("%s%.1f", text, num), true
+		}
+		return fmt.Sprintf("%s %s %s", expr, op, lit), fmt.Sprintf("%s%f", text, num), true
+	}
+
+	return "", "", false
+}
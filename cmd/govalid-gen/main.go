@@ -0,0 +1,160 @@
+// Command govalid-gen generates reflection-free Validate methods from the
+// validate, validate_if and error_message struct tags, renamed with the -tag,
+// -cond-tag and -message-tag flags.
+//
+// Add a go:generate directive next to the structs:
+//
+//	//go:generate go run github.com/harrysan/govalid/cmd/govalid-gen -type=User,Address
+//
+// For every type it writes a method
+//
+//	func (x *T) Validate() []govalid.ValidationError
+//
+// with typed checks that return the same errors as govalid.ValidateStruct
+// with the default Validator. Fields whose rules have no typed equivalent
+// (nested structs, maps, custom rules, ...) are delegated to
+// govalid.ValidatePartial, so the results stay identical. With renamed tags
+// they are delegated to GovalidValidator, a Validator declared in the
+// generated file with the same tag names, on which custom rules and aliases
+// are registered.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of type names, defaults to every struct with validation tags")
+	output := flag.String("output", "govalid_gen.go", "output file, relative to the package directory")
+	tagName := flag.String("tag", "validate", "struct tag holding the validation rules")
+	condTag := flag.String("cond-tag", "validate_if", "struct tag holding the conditional rules")
+	msgTag := flag.String("message-tag", "error_message", "struct tag holding custom error messages")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if err := run(dir, *output, *typeNames, newGenerator("", *tagName, *condTag, *msgTag)); err != nil {
+		fmt.Fprintln(os.Stderr, "govalid-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, output, typeNames string, g *generator) error {
+	pkg, err := loadPackage(dir, output)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if typeNames != "" {
+		names = strings.Split(typeNames, ",")
+	}
+
+	g.pkg = pkg.name
+	g.hooks = pkg.hooks
+	if err := g.generate(pkg.structs, names); err != nil {
+		return err
+	}
+
+	src, err := g.format()
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+	return os.WriteFile(output, src, 0o644)
+}
+
+// structType is a struct declaration found in the package
+type structType struct {
+	name string
+	node *ast.StructType
+}
+
+type pkgInfo struct {
+	name    string
+	structs []structType
+	hooks   map[string]bool // types with a ValidateWith method
+}
+
+// loadPackage parses the non-test Go files of dir matching the build
+// constraints of the current platform, skipping the output file
+func loadPackage(dir, output string) (*pkgInfo, error) {
+	fset := token.NewFileSet()
+	filter := func(fi fs.FileInfo) bool {
+		if strings.HasSuffix(fi.Name(), "_test.go") || fi.Name() == filepath.Base(output) {
+			return false
+		}
+		match, err := build.Default.MatchFile(dir, fi.Name())
+		return err == nil && match
+	}
+
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	info := &pkgInfo{hooks: map[string]bool{}}
+	for name, pkg := range pkgs {
+		info.name = name
+
+		files := make([]string, 0, len(pkg.Files))
+		for file := range pkg.Files {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			for _, decl := range pkg.Files[file].Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Name.Name == "ValidateWith" {
+					info.hooks[receiverName(fn.Recv.List[0].Type)] = true
+					continue
+				}
+
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok || ts.TypeParams != nil {
+						continue
+					}
+					info.structs = append(info.structs, structType{name: ts.Name.Name, node: st})
+				}
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// receiverName returns the type name of a method receiver
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
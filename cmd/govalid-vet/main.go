@@ -0,0 +1,29 @@
+// Command govalid-vet checks the validate, validate_if and error_message
+// struct tags of packages at build time, see package vet for the checks.
+//
+// It is a separate module, so the validator keeps its minimum Go version.
+// It requires a released validator and is installed with
+//
+//	go install github.com/harrysan/govalid/cmd/govalid-vet@latest
+//
+// and run on packages like go vet:
+//
+//	govalid-vet ./...
+//
+// or as a vet tool:
+//
+//	go vet -vettool=$(which govalid-vet) ./...
+//
+// Custom and regex rules registered outside of the checked packages are
+// named with the -rules and -regex flags, -govalid.rules and -govalid.regex
+// when run as a vet tool.
+package main
+
+import (
+	"github.com/harrysan/govalid/cmd/govalid-vet/vet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(vet.Analyzer)
+}
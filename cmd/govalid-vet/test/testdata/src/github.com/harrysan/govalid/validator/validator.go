@@ -0,0 +1,14 @@
+// Package govalid stubs the registration functions of the validator
+package govalid
+
+type CustomRule func(field string, value any) error
+
+type Validator struct{}
+
+func (v *Validator) RegisterCustomRule(name string, rule CustomRule) error { return nil }
+
+func RegisterCustomRule(name string, rule CustomRule) error { return nil }
+
+func RegisterAlias(name, tags string) error { return nil }
+
+func (v *Validator) AddOrUpdateRegexRule(name, pattern string) {}
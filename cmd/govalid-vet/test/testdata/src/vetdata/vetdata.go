@@ -0,0 +1,59 @@
+package vetdata
+
+import govalid "github.com/harrysan/govalid/validator"
+
+const phoneRule = "phone"
+
+func init() {
+	govalid.RegisterCustomRule("uppercase", nil)
+	govalid.RegisterAlias("password", "required,min=8")
+	new(govalid.Validator).RegisterCustomRule("even", nil)
+	new(govalid.Validator).AddOrUpdateRegexRule(phoneRule, `^[0-9]+$`)
+}
+
+type Address struct {
+	City string `validate:"required,min=2"`
+}
+
+type User struct {
+	Name     string            `validate:"requried,min=2"`       // want `validate tag of Name: unknown rule "requried"`
+	Age      int               `validate:"email"`                // want `validate tag of Age: email does not apply to a field of type int`
+	Active   string            `validate:"isTrue"`               // want `validate tag of Active: isTrue does not apply to a field of type string`
+	Nick     *string           `validate:"required,min=3,max=x"` // want `validate tag of Nick: invalid parameter "x" for max, expected a number`
+	Score    uint              `validate:"min=1"`                // want `validate tag of Score: min does not apply to a field of type uint`
+	Emails   []string          `validate:"email,minItems=1"`
+	Tags     []string          `validate:"len=2,dive,required,isTrue"`            // want `validate tag of Tags: isTrue does not apply to a field of type string`
+	Labels   map[string]int    `validate:"keys=(min=2;uppercase),values=(email)"` // want `validate tag of Labels: email does not apply to a field of type int`
+	Code     string            `validate:"regex=username,even"`
+	Secret   string            `validate:"password"`
+	Phone    string            `validate:"regex=phone"`
+	Zip      string            `validate:"regex=zip"` // want `validate tag of Zip: regex rule "zip" is not registered`
+	Pattern  string            `validate:"regex='^[a-z]{2,5}$'"`
+	Broken   string            `validate:"regex='[a-z'"`             // want "validate tag of Broken: invalid regex pattern"
+	Quote    string            `validate:"required,regex='^[a-z]+$"` // want `validate tag of Quote: unterminated quote`
+	Custom   string            `validate:"custom=missing"`           // want `validate tag of Custom: unknown rule "missing"`
+	Count    int               `validate:"dive,min=1"`               // want `validate tag of Count: dive requires a slice, an array or a map, got int`
+	Address  Address           `validate:"min=1"`                    // want `validate tag of Address: min does not apply to a field of type Address`
+	Any      any               `validate:"required,email"`
+	Reason   string            `validate_if:"Enabled=true,required"` // want `validate_if tag of Reason: condition field "Enabled" not found`
+	Comment  string            `validate_if:"Age=1,isFalse"`         // want `validate_if tag of Comment: isFalse does not apply to a field of type string`
+	Plan     string            `validate_if:"Age>18 && Name in (a;'b c'),min=2"`
+	Note     string            `validate_if:"Age=many,required"`                    // want `validate_if tag of Note: invalid value "many" for int`
+	Tier     string            `validate_if:"Age<3 || Name>a,required"`             // want `validate_if tag of Tier: > requires a number, a time or a duration, Name is string`
+	Extra    string            `validate_if:"Age=1"`                                // want `validate_if tag of Extra: missing rules after the condition`
+	Hint     string            `error_message:"hint is required"`                   // want `error_message tag of Hint: has no effect without a validate or validate_if tag`
+	Escaped  string            `validate:"required,min=1\\,5"`                      // want `validate tag of Escaped: invalid parameter "1,5" for min, expected a number`
+	Contact  string            `validate:"required,regex=email|regex=phone|isTrue"` // want `validate tag of Contact: isTrue does not apply to a field of type string`
+	Slug     string            `validate:"!regex=slug,!dive"`                       // want `validate tag of Slug: dive cannot be negated`
+	Optional *string           `validate:"omitnil,min=2,!omitempty"`                // want `validate tag of Optional: omitempty cannot be negated`
+	Confirm  string            `validate:"eqfield=Name"`
+	Older    string            `validate:"gtfield=Age"`                    // want `validate tag of Older: gtfield cannot compare string with int`
+	Typo     string            `validate:"eqfield=Nmae"`                   // want `validate tag of Typo: field "Nmae" not found`
+	City     string            `validate:"nefield=Address.City,eqcsfield"` // want `validate tag of City: eqcsfield requires the name of a field`
+	Card     string            `validate:"required_unless=Name bank,required_with=Age Address.City"`
+	IBAN     string            `validate:"required_if=Name"`      // want `validate tag of IBAN: required_if expects pairs of a field and a value`
+	Promo    string            `validate:"excluded_if=Age free"`  // want `validate tag of Promo: invalid value "free" for int`
+	Swift    string            `validate:"required_without=Bank"` // want `validate tag of Swift: field "Bank" not found`
+	Wire     string            `validate:"required_if=Name 'wire transfer' Age 3"`
+	Settings map[string]string `validate:"dive,required" error_message:"invalid settings"`
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/harrysan/govalid/cmd/govalid-vet/vet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestVetAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "vetdata")
+}
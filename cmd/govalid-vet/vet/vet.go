@@ -0,0 +1,652 @@
+// Package vet defines an analyzer checking the validate, validate_if and
+// error_message struct tags of a package, so mistakes the validator reports
+// or silently ignores at runtime are found at build time.
+//
+// It reports unknown rules, rules that do not apply to the kind of their
+// field (email on an int), bad parameters, validate_if conditions naming
+// missing fields and regex rules that are not registered. Custom rules,
+// aliases and regex rules registered in the analyzed package with a constant
+// name are known, the -rules and -regex flags name the ones registered elsewhere.
+package vet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrysan/govalid/internal/tag"
+	"github.com/harrysan/govalid/rules"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// modulePath is the import path of govalid, registrations are only
+// recognized in its packages
+const modulePath = "github.com/harrysan/govalid"
+
+// Analyzer checks the govalid struct tags of a package
+var Analyzer = &analysis.Analyzer{
+	Name:     "govalid",
+	Doc:      "check the validate, validate_if and error_message struct tags used by govalid",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var (
+	tagName     string
+	condTagName string
+	msgTagName  string
+	customRules string
+	regexNames  string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&tagName, "tag", "validate", "struct tag holding the validation rules")
+	Analyzer.Flags.StringVar(&condTagName, "cond-tag", "validate_if", "struct tag holding the conditional rules")
+	Analyzer.Flags.StringVar(&msgTagName, "message-tag", "error_message", "struct tag holding custom error messages")
+	Analyzer.Flags.StringVar(&customRules, "rules", "", "comma separated list of custom rules registered outside of the analyzed package")
+	Analyzer.Flags.StringVar(&regexNames, "regex", "", "comma separated list of regex rules registered outside of the analyzed package")
+}
+
+// checker holds the state of an analysis of a package
+type checker struct {
+	pass   *analysis.Pass
+	custom map[string]bool // registered custom rules
+	regex  map[string]bool // registered regex rules, besides the built-in ones
+}
+
+// field is a tag of a struct field being checked
+type field struct {
+	lit    *ast.BasicLit
+	key    string // tag key, e.g. validate
+	name   string
+	parent types.Type // struct of the field
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := &checker{pass: pass, custom: nameSet(customRules), regex: nameSet(regexNames)}
+
+	// registrations may follow the structs they are used by
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		c.registration(n.(*ast.CallExpr))
+	})
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		c.checkStruct(n.(*ast.StructType))
+	})
+	return nil, nil
+}
+
+// nameSet splits a comma separated list of names
+func nameSet(list string) map[string]bool {
+	set := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// registration records the custom and regex rules registered with a
+// constant name
+func (c *checker) registration(call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || len(call.Args) == 0 {
+		return
+	}
+	if path := fn.Pkg().Path(); path != modulePath && !strings.HasPrefix(path, modulePath+"/") {
+		return
+	}
+
+	name := c.pass.TypesInfo.Types[call.Args[0]].Value
+	if name == nil || name.Kind() != constant.String {
+		return
+	}
+
+	switch fn.Name() {
+	case "RegisterCustomRule", "RegisterCustomRuleCtx", "RegisterAlias":
+		c.custom[constant.StringVal(name)] = true
+	case "AddOrUpdateRegexRule", "AddOrUpdate":
+		c.regex[constant.StringVal(name)] = true
+	}
+}
+
+// checkStruct checks the tags of every field of a struct type
+func (c *checker) checkStruct(st *ast.StructType) {
+	typ := c.pass.TypesInfo.TypeOf(st)
+	if typ == nil {
+		return
+	}
+
+	for _, astField := range st.Fields.List {
+		if astField.Tag == nil {
+			continue
+		}
+		raw, err := strconv.Unquote(astField.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tags := reflect.StructTag(raw)
+		fieldType := c.pass.TypesInfo.TypeOf(astField.Type)
+		f := field{lit: astField.Tag, name: fieldName(astField), parent: typ}
+
+		validate, hasRules := tags.Lookup(tagName)
+		if hasRules {
+			f.key = tagName
+			c.checkTag(f, fieldType, validate)
+		}
+
+		cond, hasCond := tags.Lookup(condTagName)
+		if hasCond {
+			f.key = condTagName
+			c.checkCondition(f, typ, fieldType, cond)
+		}
+
+		if _, ok := tags.Lookup(msgTagName); ok && !hasRules && !hasCond {
+			f.key = msgTagName
+			c.report(f, 0, "has no effect without a %s or %s tag", tagName, condTagName)
+		}
+	}
+}
+
+// fieldName returns the name of a struct field, the type name for embedded fields
+func fieldName(f *ast.Field) string {
+	if len(f.Names) > 0 {
+		return f.Names[0].Name
+	}
+
+	typ := f.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return "embedded field"
+}
+
+// checkTag checks a validate tag
+func (c *checker) checkTag(f field, typ types.Type, value string) {
+	rules, err := tag.Parse(value, ",")
+	if err != nil {
+		c.syntaxError(f, err)
+		return
+	}
+	c.checkRules(f, typ, rules)
+}
+
+// checkCondition checks a validate_if tag against the struct it is used in
+func (c *checker) checkCondition(f field, structType, typ types.Type, value string) {
+	cond, err := tag.ParseCondition(value)
+	if err != nil {
+		c.syntaxError(f, err)
+		return
+	}
+
+	for _, and := range cond.Or {
+		for _, comp := range and {
+			c.checkComparison(f, structType, comp)
+		}
+	}
+	c.checkRules(f, typ, cond.Rules)
+}
+
+// checkComparison checks that the field of a comparison of a condition
+// exists and that its values parse as the type of the field
+func (c *checker) checkComparison(f field, structType types.Type, comp tag.Comparison) {
+	typ, ok := c.lookupField(structType, comp.Field)
+	if !ok {
+		c.report(f, comp.Column, "condition field %q not found", comp.Field)
+		return
+	}
+
+	class := typeClass(typ)
+	if basic, ok := typ.Underlying().(*types.Basic); ok && class == "" && basic.Info()&types.IsBoolean != 0 {
+		class = "bool"
+	}
+	switch {
+	case class == "":
+		c.report(f, comp.Column, "cannot compare %s in a condition", c.typeString(typ))
+		return
+	case comp.Op != "=" && comp.Op != "!=" && comp.Op != "in" && (class == "string" || class == "bool"):
+		c.report(f, comp.Column, "%s requires a number, a time or a duration, %s is %s", comp.Op, comp.Field, c.typeString(typ))
+		return
+	}
+
+	for _, s := range comp.Values {
+		if !parsesAs(class, s) {
+			c.report(f, comp.Column, "invalid value %q for %s", s, c.typeString(typ))
+		}
+	}
+}
+
+// parsesAs reports whether a value of a condition parses as a value of a
+// class of types, see typeClass
+func parsesAs(class, s string) bool {
+	var err error
+	switch class {
+	case "int":
+		_, err = strconv.ParseInt(s, 10, 64)
+	case "uint":
+		_, err = strconv.ParseUint(s, 10, 64)
+	case "float":
+		_, err = strconv.ParseFloat(s, 64)
+	case "bool":
+		_, err = strconv.ParseBool(s)
+	case "time":
+		_, err = time.Parse(time.RFC3339, s)
+	case "duration":
+		_, err = time.ParseDuration(s)
+	}
+	return err == nil
+}
+
+// checkRules checks rules against the type of the value they apply to, the
+// rules after a dive and the keys= and values= lists being checked against
+// the elements, keys and values
+func (c *checker) checkRules(f field, typ types.Type, list []tag.Rule) {
+	// rules apply to the value pointers point to, the dynamic type of an
+	// interface is only known at runtime
+	typ = deref(typ)
+	iface := types.IsInterface(typ)
+
+	for i, r := range list {
+		if len(r.Or) > 0 {
+			for _, alt := range r.Or {
+				if walksRule(alt.Name) {
+					c.report(f, alt.Column, "%s cannot be used in an OR group", alt.Name)
+					continue
+				}
+				c.checkRule(f, typ, alt, iface)
+			}
+			continue
+		}
+		if r.Not && walksRule(r.Name) {
+			c.report(f, r.Column, "%s cannot be negated", r.Name)
+			continue
+		}
+
+		switch r.Name {
+		case "", "bail", "omitempty", "omitnil":
+			continue
+
+		case "keys", "values":
+			m, ok := typ.Underlying().(*types.Map)
+			if !ok && !iface {
+				c.report(f, r.Column, "%s requires a map, got %s", r.Name, c.typeString(typ))
+				continue
+			}
+			sub, err := tag.ParseList(r)
+			if err != nil {
+				c.syntaxError(f, err)
+				continue
+			}
+
+			elem := typ
+			if ok && r.Name == "keys" {
+				elem = m.Key()
+			} else if ok {
+				elem = m.Elem()
+			}
+			c.checkRules(f, elem, sub)
+			continue
+
+		case "dive":
+			elem := elemType(typ)
+			if elem == nil && !iface {
+				c.report(f, r.Column, "dive requires a slice, an array or a map, got %s", c.typeString(typ))
+				return
+			}
+			if elem == nil {
+				elem = typ
+			}
+			c.checkRules(f, elem, list[i+1:])
+			return
+		}
+
+		c.checkRule(f, typ, r, iface)
+	}
+}
+
+// checkRule checks the name and the parameter of a rule and that it applies
+// to the type of its value
+func (c *checker) checkRule(f field, typ types.Type, r tag.Rule, iface bool) {
+	if !tag.Builtin(r.Name) {
+		if !c.custom[r.Name] {
+			c.report(f, r.Column, "unknown rule %q", r.Name)
+		}
+		return
+	}
+
+	switch r.Name {
+	case "min", "max":
+		if _, err := strconv.ParseFloat(r.Param, 64); err != nil {
+			c.report(f, r.Column, "invalid parameter %q for %s, expected a number", r.Param, r.Name)
+		}
+	case "len", "minItems", "maxItems":
+		if _, err := strconv.Atoi(r.Param); err != nil {
+			c.report(f, r.Column, "invalid parameter %q for %s, expected an integer", r.Param, r.Name)
+		}
+	case "custom":
+		if r.Param == "" {
+			c.report(f, r.Column, "custom requires the name of a registered rule")
+		} else if !c.custom[r.Param] {
+			c.report(f, r.Column, "unknown rule %q", r.Param)
+		}
+	case "regex":
+		c.checkRegex(f, r)
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		c.checkFieldRef(f, typ, r, iface)
+	case "required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "required_without_all", "excluded_if", "excluded_unless":
+		c.checkDependency(f, r)
+	case "eqcsfield", "necsfield", "gtcsfield", "gtecsfield", "ltcsfield", "ltecsfield":
+		if r.Param == "" {
+			c.report(f, r.Column, "%s requires the name of a field", r.Name)
+		}
+	}
+
+	if !iface && !applies(r.Name, typ) {
+		c.report(f, r.Column, "%s does not apply to a field of type %s", r.Name, c.typeString(typ))
+	}
+}
+
+// checkRegex checks that a regex rule is registered or that an inline
+// pattern compiles
+func (c *checker) checkRegex(f field, r tag.Rule) {
+	if r.Quoted {
+		if _, err := regexp.Compile(r.Param); err != nil {
+			c.report(f, r.Column, "invalid regex pattern %q: %v", r.Param, err)
+		}
+		return
+	}
+
+	if c.regex[r.Param] {
+		return
+	}
+	if _, err := rules.NewRegexRules().Get(r.Param); err != nil {
+		c.report(f, r.Column, "regex rule %q is not registered", r.Param)
+	}
+}
+
+// walksRule reports whether a rule shapes the walk of a field instead of
+// checking its value
+func walksRule(name string) bool {
+	switch name {
+	case "bail", "dive", "keys", "values", "omitempty", "omitnil":
+		return true
+	}
+	return false
+}
+
+// checkFieldRef checks that the sibling field a rule of the eqfield family
+// compares with exists and has a comparable type
+func (c *checker) checkFieldRef(f field, typ types.Type, r tag.Rule, iface bool) {
+	if r.Param == "" {
+		c.report(f, r.Column, "%s requires the name of a field", r.Name)
+		return
+	}
+
+	other, ok := c.lookupField(f.parent, r.Param)
+	if !ok {
+		c.report(f, r.Column, "field %q not found", r.Param)
+		return
+	}
+
+	if iface || types.IsInterface(other) {
+		return
+	}
+	cmp := strings.Replace(r.Name, "csfield", "field", 1)
+	if !comparableTypes(cmp, typ, other) {
+		c.report(f, r.Column, "%s cannot compare %s with %s", r.Name, c.typeString(typ), c.typeString(other))
+	}
+}
+
+// checkDependency checks the fields a rule of the required_if family
+// depends on, pairs of a field and a value for the if and unless variants
+func (c *checker) checkDependency(f field, r tag.Rule) {
+	params, err := tag.Fields(r)
+	if err != nil {
+		c.syntaxError(f, err)
+		return
+	}
+	if len(params) == 0 {
+		c.report(f, r.Column, "%s requires the name of a field", r.Name)
+		return
+	}
+
+	if !strings.HasSuffix(r.Name, "_if") && !strings.HasSuffix(r.Name, "_unless") {
+		for _, name := range params {
+			if _, ok := c.lookupField(f.parent, name); !ok {
+				c.report(f, r.Column, "field %q not found", name)
+			}
+		}
+		return
+	}
+
+	if len(params)%2 != 0 {
+		c.report(f, r.Column, "%s expects pairs of a field and a value", r.Name)
+		return
+	}
+	for i := 0; i < len(params); i += 2 {
+		c.checkComparison(f, f.parent, tag.Comparison{Field: params[i], Op: "=", Values: []string{params[i+1]}, Column: r.Column})
+	}
+}
+
+// lookupField returns the type, without pointers, of the exported field a
+// dotted path names in a struct type
+func (c *checker) lookupField(structType types.Type, path string) (types.Type, bool) {
+	typ := structType
+	for _, name := range strings.Split(path, ".") {
+		obj, _, _ := types.LookupFieldOrMethod(deref(typ), false, c.pass.Pkg, name)
+		v, ok := obj.(*types.Var)
+		if !ok || !v.Exported() {
+			return nil, false
+		}
+		typ = v.Type()
+	}
+	return deref(typ), true
+}
+
+// comparableTypes reports whether a comparison of the eqfield family applies
+// to values of two types, like the validator checks it
+func comparableTypes(cmp string, a, b types.Type) bool {
+	if ca := typeClass(a); ca != "" && ca == typeClass(b) {
+		return true
+	}
+	return (cmp == "eqfield" || cmp == "nefield") && types.Identical(a, b)
+}
+
+// typeClass returns the class of types comparing with each other
+func typeClass(typ types.Type) string {
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" {
+		switch named.Obj().Name() {
+		case "Time":
+			return "time"
+		case "Duration":
+			return "duration"
+		}
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+	switch {
+	case !ok:
+		return ""
+	case basic.Info()&types.IsUnsigned != 0:
+		return "uint"
+	case basic.Info()&types.IsInteger != 0:
+		return "int"
+	case basic.Info()&types.IsFloat != 0:
+		return "float"
+	case basic.Info()&types.IsString != 0:
+		return "string"
+	}
+	return ""
+}
+
+// applies reports whether a built-in rule applies to a value of the given
+// type, rules that do not are ignored by the validator
+func applies(rule string, typ types.Type) bool {
+	switch rule {
+	case "min", "max":
+		// slices are checked element by element
+		if s, ok := typ.Underlying().(*types.Slice); ok {
+			typ = s.Elem()
+		}
+		return isBasic(typ, types.Int, types.Int32, types.Int64, types.Float32, types.Float64, types.String)
+	case "email":
+		if s, ok := typ.Underlying().(*types.Slice); ok {
+			typ = s.Elem()
+		}
+		return isBasic(typ, types.String)
+	case "regex":
+		return isBasic(typ, types.String)
+	case "isTrue", "isFalse":
+		return isBasic(typ, types.Bool)
+	case "len":
+		return isBasic(typ, types.String) || elemType(typ) != nil
+	case "minItems", "maxItems":
+		return elemType(typ) != nil
+	case "slice":
+		_, ok := typ.Underlying().(*types.Slice)
+		return ok
+	case "maps":
+		_, ok := typ.Underlying().(*types.Map)
+		return ok
+	case "struct":
+		_, ok := typ.Underlying().(*types.Struct)
+		return ok
+	}
+	return true
+}
+
+// isBasic reports whether the underlying type of typ is one of the given kinds
+func isBasic(typ types.Type, kinds ...types.BasicKind) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	for _, kind := range kinds {
+		if basic.Kind() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// elemType returns the type of the elements of a slice or an array or of
+// the values of a map, nil for other types
+func elemType(typ types.Type) types.Type {
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Array:
+		return t.Elem()
+	case *types.Map:
+		return t.Elem()
+	}
+	return nil
+}
+
+// deref returns the type pointers point to
+func deref(typ types.Type) types.Type {
+	for {
+		ptr, ok := typ.Underlying().(*types.Pointer)
+		if !ok {
+			return typ
+		}
+		typ = ptr.Elem()
+	}
+}
+
+func (c *checker) typeString(typ types.Type) string {
+	return types.TypeString(typ, types.RelativeTo(c.pass.Pkg))
+}
+
+// syntaxError reports an error parsing a tag
+func (c *checker) syntaxError(f field, err error) {
+	if syntaxErr, ok := err.(*tag.SyntaxError); ok {
+		c.report(f, syntaxErr.Column, "%s", syntaxErr.Msg)
+		return
+	}
+	c.report(f, 0, "%v", err)
+}
+
+// report reports a problem at a column of the tag of a field, 0 being the
+// whole tag
+func (c *checker) report(f field, column int, format string, args ...any) {
+	c.pass.Reportf(tagPos(f.lit, f.key, column), "%s tag of %s: "+format, append([]any{f.key, f.name}, args...)...)
+}
+
+// tagPos returns the position of a column of the value of key in a struct
+// tag, or of the tag itself when the column cannot be mapped, e.g. in tags
+// with escapes
+func tagPos(lit *ast.BasicLit, key string, column int) token.Pos {
+	if column < 1 || !strings.HasPrefix(lit.Value, "`") {
+		return lit.Pos()
+	}
+	offset := valueOffset(lit.Value[1:len(lit.Value)-1], key)
+	if offset < 0 {
+		return lit.Pos()
+	}
+	return lit.Pos() + token.Pos(1+offset+column-1)
+}
+
+// valueOffset returns the offset of the value of key in a struct tag, or -1
+// when the key is missing or its value has escapes. It follows the syntax
+// read by reflect.StructTag.Lookup.
+func valueOffset(tag, key string) int {
+	offset := 0
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag, offset = tag[i:], offset+i
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := tag[:i]
+		tag, offset = tag[i+1:], offset+i+1
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+
+		if name == key {
+			if strings.Contains(tag[:i], `\`) {
+				return -1
+			}
+			return offset + 1
+		}
+		tag, offset = tag[i+1:], offset+i+1
+	}
+	return -1
+}
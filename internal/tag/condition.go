@@ -0,0 +1,188 @@
+package tag
+
+import (
+	"strings"
+)
+
+// Operators of the comparisons of a condition, longest first
+var operators = []string{"!=", ">=", "<=", "=", ">", "<", "in"}
+
+// Condition is a parsed validate_if tag, e.g. IsActive=true,required
+type Condition struct {
+	Or    [][]Comparison // alternatives joined with ||, each a list of comparisons joined with &&
+	Rules []Rule         // rules applying when the condition holds
+}
+
+// Comparison compares a field with one or more values, e.g. Status in (a;b)
+type Comparison struct {
+	Field  string   // dotted path of the field, e.g. Address.Country
+	Op     string   // =, !=, >, >=, <, <= or in
+	Values []string // values without quotes and escapes, the list of in
+	Column int      // 1-based column of the comparison in the tag
+}
+
+// ParseCondition parses a validate_if tag. The condition ends at the first
+// comma outside of quotes and parentheses and compares fields with values,
+// Field=Value, combined with && and ||, && binding tighter. Values may be
+// quoted with single quotes, in takes a ; separated list in parentheses,
+// e.g. Plan in (pro;team) || Seats>10,required,min=3.
+func ParseCondition(s string) (Condition, error) {
+	parts, err := split(s, ',', 1)
+	if err != nil {
+		return Condition{}, err
+	}
+	if len(parts) == 1 {
+		return Condition{}, &SyntaxError{Column: len(s) + 1, Msg: "missing rules after the condition"}
+	}
+
+	var cond Condition
+	p := &condParser{s: parts[0].text}
+	if cond.Or, err = p.parse(); err != nil {
+		return Condition{}, err
+	}
+
+	rest := parts[1].column - 1
+	if strings.TrimSpace(s[rest:]) == "" {
+		return Condition{}, &SyntaxError{Column: rest + 1, Msg: "missing rules after the condition"}
+	}
+	if cond.Rules, err = parse(s[rest:], ',', rest+1); err != nil {
+		return Condition{}, err
+	}
+	return cond, nil
+}
+
+// condParser reads the comparisons of a condition
+type condParser struct {
+	s string
+	i int
+}
+
+// parse reads comparisons joined with && and ||
+func (p *condParser) parse() ([][]Comparison, error) {
+	var or [][]Comparison
+	var and []Comparison
+
+	for {
+		c, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, c)
+
+		p.skipSpace()
+		switch {
+		case p.i == len(p.s):
+			return append(or, and), nil
+		case strings.HasPrefix(p.s[p.i:], "&&"):
+			p.i += 2
+		case strings.HasPrefix(p.s[p.i:], "||"):
+			p.i += 2
+			or, and = append(or, and), nil
+		default:
+			return nil, p.errorf("expected && or ||")
+		}
+	}
+}
+
+// comparison reads a single comparison, e.g. Amount>100
+func (p *condParser) comparison() (Comparison, error) {
+	p.skipSpace()
+	c := Comparison{Column: p.i + 1}
+
+	start := p.i
+	for p.i < len(p.s) && isFieldChar(p.s[p.i]) {
+		p.i++
+	}
+	c.Field = p.s[start:p.i]
+	if c.Field == "" {
+		return Comparison{}, p.errorf("missing field")
+	}
+
+	p.skipSpace()
+	for _, op := range operators {
+		if strings.HasPrefix(p.s[p.i:], op) {
+			c.Op = op
+			break
+		}
+	}
+	if c.Op == "" {
+		return Comparison{}, p.errorf("missing operator after " + c.Field)
+	}
+	p.i += len(c.Op)
+	p.skipSpace()
+
+	if c.Op != "in" {
+		c.Values = []string{p.value()}
+		return c, nil
+	}
+
+	if p.i == len(p.s) || p.s[p.i] != '(' {
+		return Comparison{}, p.errorf("in expects a list of values in parentheses")
+	}
+	end := p.closingParen()
+	items, err := split(p.s[p.i+1:end], ';', p.i+2)
+	if err != nil {
+		return Comparison{}, err
+	}
+	for _, item := range items {
+		c.Values = append(c.Values, unescape(strings.TrimSpace(item.text)))
+	}
+	p.i = end + 1
+	return c, nil
+}
+
+// value reads a value up to the next && or ||, spaces around it are
+// trimmed unless it is quoted
+func (p *condParser) value() string {
+	start := p.i
+	quoted := false
+	for ; p.i < len(p.s); p.i++ {
+		c := p.s[p.i]
+		switch {
+		case c == '\\' && (!quoted || p.i+1 < len(p.s) && p.s[p.i+1] == '\''):
+			p.i++
+		case c == '\'':
+			quoted = !quoted
+		case !quoted && (strings.HasPrefix(p.s[p.i:], "&&") || strings.HasPrefix(p.s[p.i:], "||")):
+			return unescape(strings.TrimSpace(p.s[start:p.i]))
+		}
+	}
+	return unescape(strings.TrimSpace(p.s[start:]))
+}
+
+// closingParen returns the index of the parenthesis closing the one at p.i,
+// split already checked the parentheses are balanced
+func (p *condParser) closingParen() int {
+	depth, quoted := 0, false
+	for i := p.i; i < len(p.s); i++ {
+		switch c := p.s[i]; {
+		case c == '\\' && (!quoted || i+1 < len(p.s) && p.s[i+1] == '\''):
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(p.s)
+}
+
+func (p *condParser) skipSpace() {
+	for p.i < len(p.s) && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+func (p *condParser) errorf(msg string) error {
+	return &SyntaxError{Column: p.i + 1, Msg: msg}
+}
+
+// isFieldChar reports whether c may be part of a dotted field path
+func isFieldChar(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,281 @@
+// Package tag parses the validate and validate_if struct tags. It is shared
+// by the validator and the govalid tools, so they always read tags the same way.
+//
+// A tag is a list of rules separated by commas, a rule being a name with an
+// optional parameter after "=". Parameters may contain separators when they
+// are quoted with single quotes, regex='^[a-z]{2,8}$', or escaped with a
+// backslash, in=a\,b. Inside quotes only \' is an escape, so regex patterns
+// keep their backslashes. Parentheses group the rule list of keys= and
+// values=, e.g. values=(keys=min=2;values=required).
+//
+// A rule may be negated with a leading !, e.g. !regex=slug, and rules joined
+// with | form an OR group passing when one of them passes, e.g.
+// regex=email|regex=phone_number. ! binds tighter than |, which binds
+// tighter than the separators of rules.
+//
+// A validate_if tag starts with a condition followed by the rules applying
+// when it holds, e.g. Method=card && Amount>100,required. See ParseCondition.
+package tag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule is a single rule of a tag, e.g. min=3
+type Rule struct {
+	Tag    string // rule as written in the tag
+	Name   string
+	Param  string // parameter without quotes and escapes
+	Quoted bool   // the whole parameter was quoted
+	Not    bool   // negated with a leading !
+	Or     []Rule // alternatives of an OR group, the group itself has no name
+	Column int    // 1-based column of the rule in the tag
+
+	rawParam    string
+	paramColumn int
+}
+
+// builtin are the rules known without registering them
+var builtin = map[string]bool{
+	"required": true, "min": true, "max": true, "len": true, "minItems": true, "maxItems": true,
+	"email": true, "isTrue": true, "isFalse": true, "slice": true, "maps": true, "struct": true,
+	"custom": true, "regex": true, "bail": true, "dive": true, "keys": true, "values": true,
+	"omitempty": true, "omitnil": true,
+	"eqfield": true, "nefield": true, "gtfield": true, "gtefield": true, "ltfield": true, "ltefield": true,
+	"eqcsfield": true, "necsfield": true, "gtcsfield": true, "gtecsfield": true, "ltcsfield": true, "ltecsfield": true,
+	"required_if": true, "required_unless": true, "required_with": true, "required_with_all": true,
+	"required_without": true, "required_without_all": true, "excluded_if": true, "excluded_unless": true,
+}
+
+// Builtin reports whether a rule is known without registering it
+func Builtin(name string) bool {
+	return builtin[name]
+}
+
+// SyntaxError is returned for a tag that cannot be parsed
+type SyntaxError struct {
+	Column int // 1-based column in the tag
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Msg, e.Column)
+}
+
+// Parse splits a tag into rules, sep being "," for tags and ";" for the
+// rule lists of keys= and values=
+func Parse(tag, sep string) ([]Rule, error) {
+	return parse(tag, sep[0], 1)
+}
+
+// ParseList parses the rule list parameter of keys= and values=, written
+// either bare (required;min=3) or in parentheses ((required;min=3))
+func ParseList(r Rule) ([]Rule, error) {
+	param, column := r.rawParam, r.paramColumn
+	if strings.HasPrefix(param, "(") && strings.HasSuffix(param, ")") {
+		param, column = param[1:len(param)-1], column+1
+	}
+	if param == "" {
+		return nil, nil
+	}
+	return parse(param, ';', column)
+}
+
+// Fields splits the parameter of a rule into fields separated by spaces,
+// fields holding spaces are quoted or escape them, e.g. the parameter of
+// required_if=Method 'wire transfer' holds Method and wire transfer
+func Fields(r Rule) ([]string, error) {
+	parts, err := split(r.rawParam, ' ', r.paramColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p.text != "" {
+			fields = append(fields, unescape(p.text))
+		}
+	}
+	return fields, nil
+}
+
+// ParseRule parses a single rule or OR group and its parameters
+func ParseRule(s string) (Rule, error) {
+	if _, err := split(s, 0, 1); err != nil {
+		return Rule{}, err
+	}
+	return parseGroup(part{text: s, column: 1})
+}
+
+func parse(s string, sep byte, column int) ([]Rule, error) {
+	parts, err := split(s, sep, column)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]Rule, 0, len(parts))
+	for _, part := range parts {
+		r, err := parseGroup(part)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// parseGroup parses a rule or an OR group of rules already checked by split
+func parseGroup(p part) (Rule, error) {
+	alternatives, err := split(p.text, '|', p.column)
+	if err != nil {
+		return Rule{}, err
+	}
+	if len(alternatives) == 1 {
+		return parseRule(p.text, p.column)
+	}
+
+	group := Rule{Tag: p.text, Column: p.column}
+	for _, alt := range alternatives {
+		r, err := parseRule(alt.text, alt.column)
+		if err != nil {
+			return Rule{}, err
+		}
+		if r.Name == "" {
+			return Rule{}, &SyntaxError{Column: alt.column, Msg: "empty alternative"}
+		}
+		group.Or = append(group.Or, r)
+	}
+	return group, nil
+}
+
+// part is a rule of a tag and its column
+type part struct {
+	text   string
+	column int
+}
+
+// split splits s on sep outside of quotes, parentheses and escapes, column
+// being the column of s in the tag
+func split(s string, sep byte, column int) ([]part, error) {
+	var parts []part
+	var parens []int
+	start, quote := 0, -1
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if quote >= 0 {
+			if c == '\\' && i+1 < len(s) && s[i+1] == '\'' {
+				i++
+			} else if c == '\'' {
+				quote = -1
+			}
+			continue
+		}
+
+		switch {
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, &SyntaxError{Column: column + i, Msg: "trailing backslash"}
+			}
+			i++
+		case c == '\'':
+			quote = i
+		case c == '(':
+			parens = append(parens, i)
+		case c == ')':
+			if len(parens) == 0 {
+				return nil, &SyntaxError{Column: column + i, Msg: "unexpected )"}
+			}
+			parens = parens[:len(parens)-1]
+		case c == sep && len(parens) == 0:
+			parts = append(parts, part{text: s[start:i], column: column + start})
+			start = i + 1
+		}
+	}
+
+	if quote >= 0 {
+		return nil, &SyntaxError{Column: column + quote, Msg: "unterminated quote"}
+	}
+	if len(parens) > 0 {
+		return nil, &SyntaxError{Column: column + parens[len(parens)-1], Msg: "missing )"}
+	}
+	return append(parts, part{text: s[start:], column: column + start}), nil
+}
+
+// parseRule parses a rule already checked by split
+func parseRule(s string, column int) (Rule, error) {
+	r := Rule{Tag: s, Column: column}
+
+	name, param, ok := cutParam(s)
+	r.Name = strings.TrimSpace(unescape(name))
+	if trimmed := strings.TrimSpace(name); strings.HasPrefix(trimmed, "!") {
+		r.Not, r.Name = true, strings.TrimSpace(unescape(trimmed[1:]))
+		if r.Name == "" {
+			return Rule{}, &SyntaxError{Column: column, Msg: "missing rule after !"}
+		}
+	}
+	if !ok {
+		return r, nil
+	}
+
+	r.rawParam, r.paramColumn = param, column+len(name)+1
+	r.Param = unescape(param)
+	r.Quoted = len(param) >= 2 && param[0] == '\'' && closingQuote(param) == len(param)-1
+	return r, nil
+}
+
+// cutParam cuts a rule at its first "=" outside of quotes and escapes
+func cutParam(s string) (name, param string, ok bool) {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && (!quoted || i+1 < len(s) && s[i+1] == '\''):
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case c == '=' && !quoted:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// closingQuote returns the index of the quote closing the one at s[0]
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == '\'' {
+			i++
+		} else if s[i] == '\'' {
+			return i
+		}
+	}
+	return -1
+}
+
+// unescape removes quotes and escapes
+func unescape(s string) string {
+	if !strings.ContainsAny(s, `\'`) {
+		return s
+	}
+
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+			b.WriteByte('\'')
+		case c == '\'':
+			quoted = !quoted
+		case !quoted && c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
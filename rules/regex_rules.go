@@ -2,54 +2,118 @@ package rules
 
 import (
 	"errors"
+	"regexp"
 	"sync"
 )
 
-// RegexRules is a map containing reusable regex validation rules
-var regexRules = struct {
+// ErrRegexRuleNotFound is returned when a regex rule name is not registered
+var ErrRegexRuleNotFound = errors.New("regex rule not found")
+
+// RegexRules is a registry of reusable regex validation rules
+type RegexRules struct {
 	sync.RWMutex
-	m map[string]string
-}{
-	m: map[string]string{
-		"email":        `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`,
-		"phone_number": `^\+?[0-9]{10,15}$`,
-		"username":     `^[a-zA-Z0-9_]{3,16}$`,
-		"zipcode":      `^[0-9]{5}(?:-[0-9]{4})?$`,
-		"url":          `/https?:\/\/(www\.)?[-a-zA-Z0-9@:%._\+~#=]{2,256}\.[a-z]{2,6}\b([-a-zA-Z0-9@:%_\+.~#()?&//=]*)/`,
-		"ipv4":         `/^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$/`,
-		"ipv6":         `/(([0-9a-fA-F]{1,4}:){7,7}[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,7}:|([0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,5}(:[0-9a-fA-F]{1,4}){1,2}|([0-9a-fA-F]{1,4}:){1,4}(:[0-9a-fA-F]{1,4}){1,3}|([0-9a-fA-F]{1,4}:){1,3}(:[0-9a-fA-F]{1,4}){1,4}|([0-9a-fA-F]{1,4}:){1,2}(:[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:((:[0-9a-fA-F]{1,4}){1,6})|:((:[0-9a-fA-F]{1,4}){1,7}|:)|fe80:(:[0-9a-fA-F]{0,4}){0,4}%[0-9a-zA-Z]{1,}|::(ffff(:0{1,4}){0,1}:){0,1}((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])|([0-9a-fA-F]{1,4}:){1,4}:((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9]))/`,
-		"slug":         `/^[a-z0-9]+(?:-[a-z0-9]+)*$/`,
-	},
+	m        map[string]string
+	compiled map[string]*regexp.Regexp
 }
 
-// GetRegexRule retrieves a regex rule by name
-func GetRegexRule(name string) (string, error) {
-	regexRules.RLock()
-	defer regexRules.RUnlock()
+// builtinRegexRules are the patterns every new registry starts with
+var builtinRegexRules = map[string]string{
+	"email":        `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`,
+	"phone_number": `^\+?[0-9]{10,15}$`,
+	"username":     `^[a-zA-Z0-9_]{3,16}$`,
+	"zipcode":      `^[0-9]{5}(?:-[0-9]{4})?$`,
+	"url":          `^(?:https?:\/\/(www\.)?[-a-zA-Z0-9@:%._\+~#=]{2,256}\.[a-z]{2,6}\b([-a-zA-Z0-9@:%_\+.~#()?&//=]*))$`,
+	"ipv4":         `^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`,
+	"ipv6":         `^(?:(([0-9a-fA-F]{1,4}:){7,7}[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,7}:|([0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,5}(:[0-9a-fA-F]{1,4}){1,2}|([0-9a-fA-F]{1,4}:){1,4}(:[0-9a-fA-F]{1,4}){1,3}|([0-9a-fA-F]{1,4}:){1,3}(:[0-9a-fA-F]{1,4}){1,4}|([0-9a-fA-F]{1,4}:){1,2}(:[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:((:[0-9a-fA-F]{1,4}){1,6})|:((:[0-9a-fA-F]{1,4}){1,7}|:)|fe80:(:[0-9a-fA-F]{0,4}){0,4}%[0-9a-zA-Z]{1,}|::(ffff(:0{1,4}){0,1}:){0,1}((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])|([0-9a-fA-F]{1,4}:){1,4}:((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])))$`,
+	"slug":         `^[a-z0-9]+(?:-[a-z0-9]+)*$`,
+}
+
+// defaultRegexRules is the registry used by the package level functions
+var defaultRegexRules = NewRegexRules()
+
+// NewRegexRules creates a registry pre-filled with the built-in regex rules
+func NewRegexRules() *RegexRules {
+	m := make(map[string]string, len(builtinRegexRules))
+	for name, pattern := range builtinRegexRules {
+		m[name] = pattern
+	}
+	return &RegexRules{m: m, compiled: map[string]*regexp.Regexp{}}
+}
+
+// DefaultRegexRules returns the registry used by the package level functions
+func DefaultRegexRules() *RegexRules {
+	return defaultRegexRules
+}
+
+// Get retrieves a regex rule by name
+func (r *RegexRules) Get(name string) (string, error) {
+	r.RLock()
+	defer r.RUnlock()
 
-	rule, exists := regexRules.m[name]
+	rule, exists := r.m[name]
 	if !exists {
-		return "", errors.New("regex rule not found")
+		return "", ErrRegexRuleNotFound
 	}
 	return rule, nil
 }
 
-// AddOrUpdateRegexRule adds or updates a regex rule
-func AddOrUpdateRegexRule(name, pattern string) {
-	regexRules.Lock()
-	defer regexRules.Unlock()
+// Regexp retrieves the compiled regex of a rule, compiling it only once
+func (r *RegexRules) Regexp(name string) (*regexp.Regexp, error) {
+	r.RLock()
+	re, exists := r.compiled[name]
+	r.RUnlock()
+	if exists {
+		return re, nil
+	}
 
-	regexRules.m[name] = pattern
+	r.Lock()
+	defer r.Unlock()
+
+	pattern, exists := r.m[name]
+	if !exists {
+		return nil, ErrRegexRuleNotFound
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	r.compiled[name] = re
+	return re, nil
 }
 
-// DeleteRegexRule removes a regex rule by name
-func DeleteRegexRule(name string) error {
-	regexRules.Lock()
-	defer regexRules.Unlock()
+// AddOrUpdate adds or updates a regex rule
+func (r *RegexRules) AddOrUpdate(name, pattern string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.m[name] = pattern
+	delete(r.compiled, name)
+}
+
+// Delete removes a regex rule by name
+func (r *RegexRules) Delete(name string) error {
+	r.Lock()
+	defer r.Unlock()
 
-	if _, exists := regexRules.m[name]; !exists {
-		return errors.New("regex rule not found")
+	if _, exists := r.m[name]; !exists {
+		return ErrRegexRuleNotFound
 	}
-	delete(regexRules.m, name)
+	delete(r.m, name)
+	delete(r.compiled, name)
 	return nil
 }
+
+// GetRegexRule retrieves a regex rule by name
+func GetRegexRule(name string) (string, error) {
+	return defaultRegexRules.Get(name)
+}
+
+// AddOrUpdateRegexRule adds or updates a regex rule
+func AddOrUpdateRegexRule(name, pattern string) {
+	defaultRegexRules.AddOrUpdate(name, pattern)
+}
+
+// DeleteRegexRule removes a regex rule by name
+func DeleteRegexRule(name string) error {
+	return defaultRegexRules.Delete(name)
+}
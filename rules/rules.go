@@ -5,20 +5,35 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 )
 
+// emailRegexp is compiled once instead of on every validated value
+var emailRegexp = regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`)
+
 type TypeParam interface {
 	int | int32 | int64 | float32 | float64
 }
 
-// check if nil / empty / 0
+// check if nil / empty / 0, an array is empty when all of its items are zero.
+// Pointers and interfaces are missing when nil and checked by their value otherwise.
 func ValidateRuleRequired(value any) error {
-	typ := reflect.TypeOf(value)
 	val := reflect.ValueOf(value)
 
-	if typ.Kind() == reflect.Slice {
-		ret := make([]interface{}, val.Len())
-		if len(ret) == 0 {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return fmt.Errorf(" field is required;")
+		}
+		val = val.Elem()
+		value = val.Interface()
+	}
+
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Map {
+		if val.Len() == 0 {
+			return fmt.Errorf(" field is required;")
+		}
+	} else if val.Kind() == reflect.Array {
+		if val.IsZero() {
 			return fmt.Errorf(" field is required;")
 		}
 	} else {
@@ -31,16 +46,15 @@ func ValidateRuleRequired(value any) error {
 }
 
 func ValidateRuleBool(value any, rule string) error {
-	typ := reflect.TypeOf(value)
 	val := reflect.ValueOf(value)
 
 	switch rule {
 	case "isTrue":
-		if typ.Kind() == reflect.Bool && !val.Bool() {
+		if val.Kind() == reflect.Bool && !val.Bool() {
 			return fmt.Errorf("value must be true")
 		}
 	case "isFalse":
-		if typ.Kind() == reflect.Bool && val.Bool() {
+		if val.Kind() == reflect.Bool && val.Bool() {
 			return fmt.Errorf("value must be false")
 		}
 	}
@@ -50,129 +64,81 @@ func ValidateRuleBool(value any, rule string) error {
 
 // validate Rule min
 func ValidateRuleMin[T TypeParam](value any, min T) error {
-	typ := reflect.TypeOf(value)
-	errors := ""
-
-	if typ.Kind() == reflect.Slice {
-		s := reflect.ValueOf(value)
-
-		for i := 0; i < s.Len(); i++ {
-			element := s.Index(i).Interface()
-			err := validateMin(element, min)
-			if err != nil {
-				strElement := fmt.Sprintf("%v", element)
-				errors = errors + "(" + strElement + ")" + err.Error() + "; "
-			}
-		}
-	} else {
-		err := validateMin(value, min)
-		if err != nil {
-			errors = errors + err.Error() + "; "
-		}
-	}
-
-	if errors != "" {
-		return fmt.Errorf(errors)
-	}
-
-	return nil
+	return eachElement(value, func(element any) error {
+		return validateMin(element, min)
+	})
 }
 
-// validate Rule min
-func ValidateRuleMax[T TypeParam](value any, min T) error {
-	typ := reflect.TypeOf(value)
-	errors := ""
-
-	if typ.Kind() == reflect.Slice {
-		s := reflect.ValueOf(value)
-
-		for i := 0; i < s.Len(); i++ {
-			element := s.Index(i).Interface()
-			err := validateMax(element, min)
-			if err != nil {
-				strElement := fmt.Sprintf("%v", element)
-				errors = errors + "(" + strElement + ")" + err.Error() + "; "
-			}
-		}
-	} else {
-		err := validateMax(value, min)
-		if err != nil {
-			errors = errors + err.Error() + "; "
-		}
-	}
-
-	if errors != "" {
-		return fmt.Errorf(errors)
-	}
-
-	return nil
+// validate Rule max
+func ValidateRuleMax[T TypeParam](value any, max T) error {
+	return eachElement(value, func(element any) error {
+		return validateMax(element, max)
+	})
 }
 
 // validate Rule email
 func ValidateRuleEmail(value any) error {
-	typ := reflect.TypeOf(value)
-	errors := ""
+	return eachElement(value, validateEmail)
+}
 
-	if typ.Kind() == reflect.Slice {
-		s := reflect.ValueOf(value)
+// eachElement applies check to every element of a slice, or to value when
+// it is not a slice, joining the errors of the failing elements
+func eachElement(value any, check func(any) error) error {
+	var b strings.Builder
 
+	if s := reflect.ValueOf(value); s.Kind() == reflect.Slice {
 		for i := 0; i < s.Len(); i++ {
 			element := s.Index(i).Interface()
-			err := validateEmail(element)
-			if err != nil {
-				strElement := fmt.Sprintf("%v", element)
-				errors = errors + "(" + strElement + ")" + err.Error() + "; "
+			if err := check(element); err != nil {
+				fmt.Fprintf(&b, "(%v)%s; ", element, err)
 			}
 		}
-	} else {
-		err := validateEmail(value)
-		if err != nil {
-			errors = errors + err.Error() + "; "
-		}
+	} else if err := check(value); err != nil {
+		b.WriteString(err.Error())
+		b.WriteString("; ")
 	}
 
-	if errors != "" {
-		return fmt.Errorf(errors)
+	if b.Len() > 0 {
+		return errors.New(b.String())
 	}
-
 	return nil
 }
 
 // validate min value
 func validateMin[T TypeParam](value any, min T) error {
-	typ := reflect.TypeOf(value)
+	kind := reflect.ValueOf(value).Kind()
 
-	if typ.Kind() == reflect.Int {
+	if kind == reflect.Int {
 		v, _ := value.(int)
 
 		if v < int(min) {
 			return fmt.Errorf(" must be greater than or equal to %d", int(min))
 		}
-	} else if typ.Kind() == reflect.Int32 {
+	} else if kind == reflect.Int32 {
 		v, _ := value.(int32)
 
 		if v < int32(min) {
 			return fmt.Errorf(" must be greater than or equal to %d", int32(min))
 		}
-	} else if typ.Kind() == reflect.Int64 {
+	} else if kind == reflect.Int64 {
 		v, _ := value.(int64)
 
 		if v < int64(min) {
 			return fmt.Errorf(" must be greater than or equal to %d", int64(min))
 		}
-	} else if typ.Kind() == reflect.String {
+	} else if kind == reflect.String {
 		v, _ := value.(string)
 
 		if len(v) < int(min) {
 			return fmt.Errorf(" must be greater than or equal to %d", int(min))
 		}
-	} else if typ.Kind() == reflect.Float64 {
+	} else if kind == reflect.Float64 {
 		v, _ := value.(float64)
 
 		if v < float64(min) {
 			return fmt.Errorf(" must be greater than or equal to %f", float64(min))
 		}
-	} else if typ.Kind() == reflect.Float32 {
+	} else if kind == reflect.Float32 {
 		v, _ := value.(float32)
 
 		if v < float32(min) {
@@ -185,39 +151,39 @@ func validateMin[T TypeParam](value any, min T) error {
 
 // validate max value
 func validateMax[T TypeParam](value any, max T) error {
-	typ := reflect.TypeOf(value)
+	kind := reflect.ValueOf(value).Kind()
 
-	if typ.Kind() == reflect.Int {
+	if kind == reflect.Int {
 		v, _ := value.(int)
 
 		if v > int(max) {
 			return fmt.Errorf(" must be less than or equal to %d", int(max))
 		}
-	} else if typ.Kind() == reflect.Int32 {
+	} else if kind == reflect.Int32 {
 		v, _ := value.(int32)
 
 		if v > int32(max) {
 			return fmt.Errorf(" must be less than or equal to %d", int32(max))
 		}
-	} else if typ.Kind() == reflect.Int64 {
+	} else if kind == reflect.Int64 {
 		v, _ := value.(int64)
 
 		if v > int64(max) {
 			return fmt.Errorf(" must be less than or equal to %d", int64(max))
 		}
-	} else if typ.Kind() == reflect.String {
+	} else if kind == reflect.String {
 		v, _ := value.(string)
 
 		if len(v) > int(max) {
 			return fmt.Errorf(" must be less than or equal to %d", int(max))
 		}
-	} else if typ.Kind() == reflect.Float32 {
+	} else if kind == reflect.Float32 {
 		v, _ := value.(float32)
 
 		if v > float32(max) {
 			return fmt.Errorf(" must be less than or equal to %.1f", float32(max))
 		}
-	} else if typ.Kind() == reflect.Float64 {
+	} else if kind == reflect.Float64 {
 		v, _ := value.(float64)
 
 		if v > float64(max) {
@@ -234,18 +200,22 @@ func validateEmail(value any) error {
 	if !ok {
 		return errors.New("email validation only supports strings")
 	}
-	re := regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`)
-	if !re.MatchString(v) {
+	if !MatchEmail(v) {
 		return errors.New(" invalid email format")
 	}
 
 	return nil
 }
 
+// MatchEmail reports whether s has a valid email format
+func MatchEmail(s string) bool {
+	return emailRegexp.MatchString(s)
+}
+
 func ValidateRuleSlice(value any) error {
-	typ := reflect.TypeOf(value)
+	kind := reflect.ValueOf(value).Kind()
 
-	if typ.Kind() != reflect.Slice {
+	if kind != reflect.Slice {
 		return fmt.Errorf("value must be slice")
 	}
 
@@ -253,9 +223,9 @@ func ValidateRuleSlice(value any) error {
 }
 
 func ValidateRuleMap(value any) error {
-	typ := reflect.TypeOf(value)
+	kind := reflect.ValueOf(value).Kind()
 
-	if typ.Kind() != reflect.Map {
+	if kind != reflect.Map {
 		return fmt.Errorf("value must be map")
 	}
 
@@ -263,9 +233,9 @@ func ValidateRuleMap(value any) error {
 }
 
 func ValidateRuleStruct(data any) error {
-	t := reflect.TypeOf(data)
+	kind := reflect.ValueOf(data).Kind()
 
-	if t.Kind() != reflect.Struct {
+	if kind != reflect.Struct {
 		return fmt.Errorf(" value must be struct")
 	}
 
@@ -273,17 +243,26 @@ func ValidateRuleStruct(data any) error {
 }
 
 func ValidateRuleRegex(value any, pattern string) error {
-	v, ok := value.(string)
-	if !ok {
+	if _, ok := value.(string); !ok {
 		return fmt.Errorf(" regex validation only supports strings;")
 	}
 
-	matched, err := regexp.MatchString(pattern, v)
+	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return fmt.Errorf(" invalid regex pattern for %s;", value)
 	}
 
-	if !matched {
+	return ValidateRuleRegexp(value, re)
+}
+
+// ValidateRuleRegexp validates a value against an already compiled regex
+func ValidateRuleRegexp(value any, re *regexp.Regexp) error {
+	v, ok := value.(string)
+	if !ok {
+		return fmt.Errorf(" regex validation only supports strings;")
+	}
+
+	if !re.MatchString(v) {
 		return fmt.Errorf(" %s does not match the required pattern;", value)
 	}
 
@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Compare compares two values of the same kind family (signed integers,
+// unsigned integers, floats or strings) or two time.Time, returning -1, 0 or 1
+func Compare(a, b any) (int, error) {
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+
+	if !va.IsValid() || !vb.IsValid() {
+		return 0, fmt.Errorf(" cannot compare %T with %T", a, b)
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb), nil
+		}
+	}
+
+	switch {
+	case isInt(va.Kind()) && isInt(vb.Kind()):
+		return cmp.Compare(va.Int(), vb.Int()), nil
+	case isUint(va.Kind()) && isUint(vb.Kind()):
+		return cmp.Compare(va.Uint(), vb.Uint()), nil
+	case isFloat(va.Kind()) && isFloat(vb.Kind()):
+		return cmp.Compare(va.Float(), vb.Float()), nil
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return cmp.Compare(va.String(), vb.String()), nil
+	}
+
+	return 0, fmt.Errorf(" cannot compare %T with %T", a, b)
+}
+
+// ValidateRuleCompare validates a value against another one with one of the
+// eqfield, nefield, gtfield, gtefield, ltfield or ltefield rules
+func ValidateRuleCompare(value, other any, rule string) error {
+	return compareWith(value, other, rule, fmt.Sprint(other))
+}
+
+// ValidateRuleCompareField is ValidateRuleCompare for the value of another
+// field, errors name the field instead of showing its value
+func ValidateRuleCompareField(value, other any, rule, field string) error {
+	return compareWith(value, other, rule, field)
+}
+
+// compareWith compares value with other, naming other label in errors
+func compareWith(value, other any, rule, label string) error {
+	if rule == "eqfield" || rule == "nefield" {
+		equal := reflect.DeepEqual(value, other)
+		if c, err := Compare(value, other); err == nil {
+			equal = c == 0
+		} else if reflect.TypeOf(value) != reflect.TypeOf(other) {
+			return err
+		}
+
+		if rule == "eqfield" && !equal {
+			return fmt.Errorf(" must be equal to %s", label)
+		}
+		if rule == "nefield" && equal {
+			return fmt.Errorf(" must not be equal to %s", label)
+		}
+		return nil
+	}
+
+	c, err := Compare(value, other)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case rule == "gtfield" && c <= 0:
+		return fmt.Errorf(" must be greater than %s", label)
+	case rule == "gtefield" && c < 0:
+		return fmt.Errorf(" must be greater than or equal to %s", label)
+	case rule == "ltfield" && c >= 0:
+		return fmt.Errorf(" must be less than %s", label)
+	case rule == "ltefield" && c > 0:
+		return fmt.Errorf(" must be less than or equal to %s", label)
+	}
+
+	return nil
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
@@ -4,6 +4,11 @@ import (
 	"reflect"
 )
 
+// ValidateField applies a rule of a validate_if tag to a field of a struct,
+// only required is supported.
+//
+// Deprecated: the validator applies validate_if tags itself, with every rule
+// and only when their condition holds.
 func ValidateField(data interface{}, field reflect.StructField, rule string) error {
 	value := reflect.ValueOf(data).FieldByName(field.Name)
 
@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ValidateRuleLen checks the number of items of a slice, array or map, or
+// the length of a string
+func ValidateRuleLen(value any, n int) error {
+	val := reflect.ValueOf(value)
+
+	switch val.Kind() {
+	case reflect.String:
+		if val.Len() != n {
+			return fmt.Errorf(" must be exactly %d characters long", n)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if val.Len() != n {
+			return fmt.Errorf(" must contain exactly %d items, got %d", n, val.Len())
+		}
+	default:
+		return fmt.Errorf(" len only supports strings, slices, arrays and maps")
+	}
+
+	return nil
+}
+
+// ValidateRuleMinItems checks that a slice, array or map has at least n items
+func ValidateRuleMinItems(value any, n int) error {
+	val := reflect.ValueOf(value)
+
+	if !isCollection(val.Kind()) {
+		return fmt.Errorf(" minItems only supports slices, arrays and maps")
+	}
+	if val.Len() < n {
+		return fmt.Errorf(" must contain at least %d items, got %d", n, val.Len())
+	}
+
+	return nil
+}
+
+// ValidateRuleMaxItems checks that a slice, array or map has at most n items
+func ValidateRuleMaxItems(value any, n int) error {
+	val := reflect.ValueOf(value)
+
+	if !isCollection(val.Kind()) {
+		return fmt.Errorf(" maxItems only supports slices, arrays and maps")
+	}
+	if val.Len() > n {
+		return fmt.Errorf(" must contain at most %d items, got %d", n, val.Len())
+	}
+
+	return nil
+}
+
+func isCollection(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
@@ -0,0 +1,64 @@
+// Code generated by govalid-gen. DO NOT EDIT.
+
+package binding
+
+import (
+	"errors"
+
+	"github.com/harrysan/govalid/rules"
+	govalid "github.com/harrysan/govalid/validator"
+)
+
+// GovalidValidator validates the fields without typed checks, reading the same tags and
+// the default regex rules. Register custom rules and aliases on it.
+var GovalidValidator = govalid.New(govalid.WithRegexRules(rules.DefaultRegexRules()), govalid.WithTagName("binding"), govalid.WithConditionTagName("binding_if"), govalid.WithMessageTagName("binding_message"))
+
+// Validate validates Profile like GovalidValidator.ValidateStruct, without reflection
+func (x *Profile) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Name `binding:"required,min=3" validate:"max=1"`
+	if x.Name == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "required", Value: x.Name, Err: errors.New(" field is required;")})
+	}
+	if len(x.Name) < 3 {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "min=3", Value: x.Name, Err: errors.New(" must be greater than or equal to 3; ")})
+	}
+
+	// Nick `binding:"min=2" binding_message:"nick is too short"`
+	if len(x.Nick) < 2 {
+		errs = append(errs, govalid.ValidationError{Field: "Nick", StructField: "Nick", Tag: "min=2", Value: x.Nick, Err: errors.New("nick is too short")})
+	}
+
+	// Tags `binding:"keys=min=3"`
+	errs = append(errs, GovalidValidator.ValidatePartial(x, "Tags")...)
+
+	// Reason `binding_if:"Active=true,required"`
+	errs = append(errs, GovalidValidator.ValidatePartial(x, "Reason")...)
+
+	// Emails `binding:"dive,email"`
+	errs = append(errs, GovalidValidator.ValidatePartial(x, "Emails")...)
+
+	// Codes `binding:"dive,regex=code"`
+	errs = append(errs, GovalidValidator.ValidatePartial(x, "Codes")...)
+
+	// Team `binding:"upper"`
+	errs = append(errs, GovalidValidator.ValidatePartial(x, "Team")...)
+
+	// Home
+	errs = append(errs, GovalidValidator.ValidatePartial(x, "Home")...)
+
+	return errs
+}
+
+// Validate validates Home like GovalidValidator.ValidateStruct, without reflection
+func (x *Home) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// City `binding:"required"`
+	if x.City == "" {
+		errs = append(errs, govalid.ValidationError{Field: "City", StructField: "City", Tag: "required", Value: x.City, Err: errors.New(" field is required;")})
+	}
+
+	return errs
+}
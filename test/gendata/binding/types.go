@@ -0,0 +1,23 @@
+// Package binding holds structs using renamed tags, to check that the code
+// generated with -tag, -cond-tag and -message-tag reads the same tags as
+// the reflective fallbacks. The tests register the upper custom rule on
+// GovalidValidator and the code regex rule on rules.DefaultRegexRules.
+package binding
+
+//go:generate go run ../../../cmd/govalid-gen -tag binding -cond-tag binding_if -message-tag binding_message
+
+type Profile struct {
+	Name   string            `binding:"required,min=3" validate:"max=1"`
+	Nick   string            `binding:"min=2" binding_message:"nick is too short"`
+	Tags   map[string]string `binding:"keys=min=3"`
+	Active bool
+	Reason string   `binding_if:"Active=true,required"`
+	Emails []string `binding:"dive,email"`
+	Codes  []string `binding:"dive,regex=code"`
+	Team   string   `binding:"upper"`
+	Home   Home
+}
+
+type Home struct {
+	City string `binding:"required"`
+}
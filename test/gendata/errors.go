@@ -0,0 +1,18 @@
+//go:build ignore
+
+// Prints the errors the generated code returns for an empty User, to compare
+// them with govalid.ValidateStruct by hand: go run errors.go
+package main
+
+import (
+	"fmt"
+
+	"github.com/harrysan/govalid/test/gendata"
+	govalid "github.com/harrysan/govalid/validator"
+)
+
+func main() {
+	user := &gendata.User{}
+	fmt.Println(user.Validate())
+	fmt.Println(govalid.ValidateStruct(user))
+}
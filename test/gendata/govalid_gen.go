@@ -0,0 +1,566 @@
+// Code generated by govalid-gen. DO NOT EDIT.
+
+package gendata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/harrysan/govalid/rules"
+	govalid "github.com/harrysan/govalid/validator"
+)
+
+// Validate validates Address like govalid.ValidateStruct, without reflection
+func (x *Address) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// City `validate:"required"`
+	if x.City == "" {
+		errs = append(errs, govalid.ValidationError{Field: "City", StructField: "City", Tag: "required", Value: x.City, Err: errors.New(" field is required;")})
+	}
+
+	// ZipCode `validate:"required,min=5"`
+	if x.ZipCode == "" {
+		errs = append(errs, govalid.ValidationError{Field: "ZipCode", StructField: "ZipCode", Tag: "required", Value: x.ZipCode, Err: errors.New(" field is required;")})
+	}
+	if len(x.ZipCode) < 5 {
+		errs = append(errs, govalid.ValidationError{Field: "ZipCode", StructField: "ZipCode", Tag: "min=5", Value: x.ZipCode, Err: errors.New(" must be greater than or equal to 5; ")})
+	}
+
+	return errs
+}
+
+// Validate validates User like govalid.ValidateStruct, without reflection
+func (x *User) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Name `validate:"required,min=3,max=10"`
+	if x.Name == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "required", Value: x.Name, Err: errors.New(" field is required;")})
+	}
+	if len(x.Name) < 3 {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "min=3", Value: x.Name, Err: errors.New(" must be greater than or equal to 3; ")})
+	}
+	if len(x.Name) > 10 {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "max=10", Value: x.Name, Err: errors.New(" must be less than or equal to 10; ")})
+	}
+
+	// Age `validate:"min=18,max=99"`
+	if x.Age < 18 {
+		errs = append(errs, govalid.ValidationError{Field: "Age", StructField: "Age", Tag: "min=18", Value: x.Age, Err: errors.New(" must be greater than or equal to 18; ")})
+	}
+	if x.Age > 99 {
+		errs = append(errs, govalid.ValidationError{Field: "Age", StructField: "Age", Tag: "max=99", Value: x.Age, Err: errors.New(" must be less than or equal to 99; ")})
+	}
+
+	// Email `validate:"required,email"`
+	if x.Email == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Email", StructField: "Email", Tag: "required", Value: x.Email, Err: errors.New(" field is required;")})
+	}
+	if !rules.MatchEmail(x.Email) {
+		errs = append(errs, govalid.ValidationError{Field: "Email", StructField: "Email", Tag: "email", Value: x.Email, Err: errors.New(" invalid email format; ")})
+	}
+
+	// Username `validate:"required,regex=username"`
+	if x.Username == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Username", StructField: "Username", Tag: "required", Value: x.Username, Err: errors.New(" field is required;")})
+	}
+	if re, err := rules.DefaultRegexRules().Regexp("username"); errors.Is(err, rules.ErrRegexRuleNotFound) {
+		errs = append(errs, govalid.ValidationError{Field: "Username", StructField: "Username", Tag: "regex=username", Value: x.Username, Err: errors.New("regex rule username not found for field Username")})
+	} else if err != nil {
+		errs = append(errs, govalid.ValidationError{Field: "Username", StructField: "Username", Tag: "regex=username", Value: x.Username, Err: errors.New(" invalid regex pattern for " + x.Username + ";")})
+	} else if !re.MatchString(x.Username) {
+		errs = append(errs, govalid.ValidationError{Field: "Username", StructField: "Username", Tag: "regex=username", Value: x.Username, Err: errors.New(" " + x.Username + " does not match the required pattern;")})
+	}
+
+	// Phone `validate:"regex=missing"`
+	if re, err := rules.DefaultRegexRules().Regexp("missing"); errors.Is(err, rules.ErrRegexRuleNotFound) {
+		errs = append(errs, govalid.ValidationError{Field: "Phone", StructField: "Phone", Tag: "regex=missing", Value: x.Phone, Err: errors.New("regex rule missing not found for field Phone")})
+	} else if err != nil {
+		errs = append(errs, govalid.ValidationError{Field: "Phone", StructField: "Phone", Tag: "regex=missing", Value: x.Phone, Err: errors.New(" invalid regex pattern for " + x.Phone + ";")})
+	} else if !re.MatchString(x.Phone) {
+		errs = append(errs, govalid.ValidationError{Field: "Phone", StructField: "Phone", Tag: "regex=missing", Value: x.Phone, Err: errors.New(" " + x.Phone + " does not match the required pattern;")})
+	}
+
+	// IsActive `validate:"isTrue"`
+	if !x.IsActive {
+		errs = append(errs, govalid.ValidationError{Field: "IsActive", StructField: "IsActive", Tag: "isTrue", Value: x.IsActive, Err: errors.New("value must be true")})
+	}
+
+	// IsBanned `validate:"isFalse"`
+	if x.IsBanned {
+		errs = append(errs, govalid.ValidationError{Field: "IsBanned", StructField: "IsBanned", Tag: "isFalse", Value: x.IsBanned, Err: errors.New("value must be false")})
+	}
+
+	// Reason `validate_if:"IsActive=true,required"`
+	errs = append(errs, govalid.ValidatePartial(x, "Reason")...)
+
+	// Address `validate:"struct"`
+	errs = append(errs, govalid.ValidatePartial(x, "Address")...)
+
+	// Tags `validate:"maps,keys=required;min=3,values=required;min=5"`
+	errs = append(errs, govalid.ValidatePartial(x, "Tags")...)
+
+	return errs
+}
+
+// Validate validates Numbers like govalid.ValidateStruct, without reflection
+func (x *Numbers) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Int `validate:"required,min=1,max=10"`
+	if x.Int == 0 {
+		errs = append(errs, govalid.ValidationError{Field: "Int", StructField: "Int", Tag: "required", Value: x.Int, Err: errors.New(" field is required;")})
+	}
+	if x.Int < 1 {
+		errs = append(errs, govalid.ValidationError{Field: "Int", StructField: "Int", Tag: "min=1", Value: x.Int, Err: errors.New(" must be greater than or equal to 1; ")})
+	}
+	if x.Int > 10 {
+		errs = append(errs, govalid.ValidationError{Field: "Int", StructField: "Int", Tag: "max=10", Value: x.Int, Err: errors.New(" must be less than or equal to 10; ")})
+	}
+
+	// Int32 `validate:"min=1,max=10"`
+	if x.Int32 < 1 {
+		errs = append(errs, govalid.ValidationError{Field: "Int32", StructField: "Int32", Tag: "min=1", Value: x.Int32, Err: errors.New(" must be greater than or equal to 1; ")})
+	}
+	if x.Int32 > 10 {
+		errs = append(errs, govalid.ValidationError{Field: "Int32", StructField: "Int32", Tag: "max=10", Value: x.Int32, Err: errors.New(" must be less than or equal to 10; ")})
+	}
+
+	// Int64 `validate:"required,min=1,max=10"`
+	if x.Int64 < 1 {
+		errs = append(errs, govalid.ValidationError{Field: "Int64", StructField: "Int64", Tag: "min=1", Value: x.Int64, Err: errors.New(" must be greater than or equal to 1; ")})
+	}
+	if x.Int64 > 10 {
+		errs = append(errs, govalid.ValidationError{Field: "Int64", StructField: "Int64", Tag: "max=10", Value: x.Int64, Err: errors.New(" must be less than or equal to 10; ")})
+	}
+
+	// Float32 `validate:"min=1.5,max=10.25"`
+	if x.Float32 < float32(1.5) {
+		errs = append(errs, govalid.ValidationError{Field: "Float32", StructField: "Float32", Tag: "min=1.5", Value: x.Float32, Err: errors.New(" must be greater than or equal to 1.500000; ")})
+	}
+	if x.Float32 > float32(10.25) {
+		errs = append(errs, govalid.ValidationError{Field: "Float32", StructField: "Float32", Tag: "max=10.25", Value: x.Float32, Err: errors.New(" must be less than or equal to 10.2; ")})
+	}
+
+	// Float64 `validate:"min=1.5,max=10.25"`
+	if x.Float64 < 1.5 {
+		errs = append(errs, govalid.ValidationError{Field: "Float64", StructField: "Float64", Tag: "min=1.5", Value: x.Float64, Err: errors.New(" must be greater than or equal to 1.500000; ")})
+	}
+	if x.Float64 > 10.25 {
+		errs = append(errs, govalid.ValidationError{Field: "Float64", StructField: "Float64", Tag: "max=10.25", Value: x.Float64, Err: errors.New(" must be less than or equal to 10.2; ")})
+	}
+
+	// Uint `validate:"min=1,max=10"`
+
+	// Bool `validate:"required,min=1,email"`
+	errs = append(errs, govalid.ValidationError{Field: "Bool", StructField: "Bool", Tag: "email", Value: x.Bool, Err: errors.New("email validation only supports strings; ")})
+
+	return errs
+}
+
+// Validate validates Slices like govalid.ValidateStruct, without reflection
+func (x *Slices) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Names `validate:"slice,required,min=3,max=5"`
+	if len(x.Names) == 0 {
+		errs = append(errs, govalid.ValidationError{Field: "Names", StructField: "Names", Tag: "required", Value: x.Names, Err: errors.New(" field is required;")})
+	}
+	{
+		msg := ""
+		for _, e := range x.Names {
+			if len(e) < 3 {
+				msg += "(" + e + ")" + " must be greater than or equal to 3; "
+			}
+		}
+		if msg != "" {
+			errs = append(errs, govalid.ValidationError{Field: "Names", StructField: "Names", Tag: "min=3", Value: x.Names, Err: errors.New(msg)})
+		}
+	}
+	{
+		msg := ""
+		for _, e := range x.Names {
+			if len(e) > 5 {
+				msg += "(" + e + ")" + " must be less than or equal to 5; "
+			}
+		}
+		if msg != "" {
+			errs = append(errs, govalid.ValidationError{Field: "Names", StructField: "Names", Tag: "max=5", Value: x.Names, Err: errors.New(msg)})
+		}
+	}
+
+	// Ages `validate:"slice,max=30"`
+	{
+		msg := ""
+		for _, e := range x.Ages {
+			if e > 30 {
+				msg += "(" + fmt.Sprint(e) + ")" + " must be less than or equal to 30; "
+			}
+		}
+		if msg != "" {
+			errs = append(errs, govalid.ValidationError{Field: "Ages", StructField: "Ages", Tag: "max=30", Value: x.Ages, Err: errors.New(msg)})
+		}
+	}
+
+	// Scores `validate:"min=0.5"`
+	{
+		msg := ""
+		for _, e := range x.Scores {
+			if e < 0.5 {
+				msg += "(" + fmt.Sprint(e) + ")" + " must be greater than or equal to 0.500000; "
+			}
+		}
+		if msg != "" {
+			errs = append(errs, govalid.ValidationError{Field: "Scores", StructField: "Scores", Tag: "min=0.5", Value: x.Scores, Err: errors.New(msg)})
+		}
+	}
+
+	// Emails `validate:"slice,email"`
+	{
+		msg := ""
+		for _, e := range x.Emails {
+			if !rules.MatchEmail(e) {
+				msg += "(" + e + ")" + " invalid email format; "
+			}
+		}
+		if msg != "" {
+			errs = append(errs, govalid.ValidationError{Field: "Emails", StructField: "Emails", Tag: "email", Value: x.Emails, Err: errors.New(msg)})
+		}
+	}
+
+	// IDs `validate:"email"`
+	{
+		msg := ""
+		for _, e := range x.IDs {
+			msg += "(" + fmt.Sprint(e) + ")" + "email validation only supports strings; "
+		}
+		if msg != "" {
+			errs = append(errs, govalid.ValidationError{Field: "IDs", StructField: "IDs", Tag: "email", Value: x.IDs, Err: errors.New(msg)})
+		}
+	}
+
+	// Flags `validate:"slice,isTrue"`
+
+	return errs
+}
+
+// Validate validates Kinds like govalid.ValidateStruct, without reflection
+func (x *Kinds) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// NotSlice `validate:"slice"`
+	errs = append(errs, govalid.ValidationError{Field: "NotSlice", StructField: "NotSlice", Tag: "slice", Value: x.NotSlice, Err: errors.New("value must be slice")})
+
+	// NotMap `validate:"maps"`
+	errs = append(errs, govalid.ValidationError{Field: "NotMap", StructField: "NotMap", Tag: "maps", Value: x.NotMap, Err: errors.New("value must be map")})
+
+	// NotStruct `validate:"struct"`
+	errs = append(errs, govalid.ValidationError{Field: "NotStruct", StructField: "NotStruct", Tag: "struct", Value: x.NotStruct, Err: errors.New(" value must be struct")})
+
+	// Message `validate:"min=18" error_message:"Age must be at least 18"`
+	if x.Message < 18 {
+		errs = append(errs, govalid.ValidationError{Field: "Message", StructField: "Message", Tag: "min=18", Value: x.Message, Err: errors.New("Age must be at least 18")})
+	}
+
+	// Regex `validate:"regex=email" error_message:"Invalid email format"`
+	if re, err := rules.DefaultRegexRules().Regexp("email"); errors.Is(err, rules.ErrRegexRuleNotFound) {
+		errs = append(errs, govalid.ValidationError{Field: "Regex", StructField: "Regex", Tag: "regex=email", Value: x.Regex, Err: errors.New("Invalid email format")})
+	} else if err != nil {
+		errs = append(errs, govalid.ValidationError{Field: "Regex", StructField: "Regex", Tag: "regex=email", Value: x.Regex, Err: errors.New("Invalid email format")})
+	} else if !re.MatchString(x.Regex) {
+		errs = append(errs, govalid.ValidationError{Field: "Regex", StructField: "Regex", Tag: "regex=email", Value: x.Regex, Err: errors.New("Invalid email format")})
+	}
+
+	// Bail `validate:"bail,required,min=3,email"`
+	{
+		n := len(errs)
+		if x.Bail == "" {
+			errs = append(errs, govalid.ValidationError{Field: "Bail", StructField: "Bail", Tag: "required", Value: x.Bail, Err: errors.New(" field is required;")})
+		}
+		if len(errs) == n {
+			if len(x.Bail) < 3 {
+				errs = append(errs, govalid.ValidationError{Field: "Bail", StructField: "Bail", Tag: "min=3", Value: x.Bail, Err: errors.New(" must be greater than or equal to 3; ")})
+			}
+		}
+		if len(errs) == n {
+			if !rules.MatchEmail(x.Bail) {
+				errs = append(errs, govalid.ValidationError{Field: "Bail", StructField: "Bail", Tag: "email", Value: x.Bail, Err: errors.New(" invalid email format; ")})
+			}
+		}
+	}
+
+	// Custom `validate:"custom=isOdd"`
+	errs = append(errs, govalid.ValidatePartial(x, "Custom")...)
+
+	// Compare `validate:"gtfield=Custom"`
+	errs = append(errs, govalid.ValidatePartial(x, "Compare")...)
+
+	return errs
+}
+
+// Validate validates Period like govalid.ValidateStruct, without reflection
+func (x *Period) Validate() []govalid.ValidationError {
+	return govalid.ValidateStruct(x)
+}
+
+// Validate validates Geo like govalid.ValidateStruct, without reflection
+func (x *Geo) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Lat `validate:"min=-90,max=90"`
+	if x.Lat < -90 {
+		errs = append(errs, govalid.ValidationError{Field: "Lat", StructField: "Lat", Tag: "min=-90", Value: x.Lat, Err: errors.New(" must be greater than or equal to -90.000000; ")})
+	}
+	if x.Lat > 90 {
+		errs = append(errs, govalid.ValidationError{Field: "Lat", StructField: "Lat", Tag: "max=90", Value: x.Lat, Err: errors.New(" must be less than or equal to 90.0; ")})
+	}
+
+	// Lng `validate:"min=-180,max=180"`
+	if x.Lng < -180 {
+		errs = append(errs, govalid.ValidationError{Field: "Lng", StructField: "Lng", Tag: "min=-180", Value: x.Lng, Err: errors.New(" must be greater than or equal to -180.000000; ")})
+	}
+	if x.Lng > 180 {
+		errs = append(errs, govalid.ValidationError{Field: "Lng", StructField: "Lng", Tag: "max=180", Value: x.Lng, Err: errors.New(" must be less than or equal to 180.0; ")})
+	}
+
+	return errs
+}
+
+// Validate validates Location like govalid.ValidateStruct, without reflection
+func (x *Location) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Name `validate:"required"`
+	if x.Name == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "required", Value: x.Name, Err: errors.New(" field is required;")})
+	}
+
+	// Geo
+	errs = append(errs, govalid.ValidatePartial(x, "Geo")...)
+
+	return errs
+}
+
+// Validate validates Audit like govalid.ValidateStruct, without reflection
+func (x *Audit) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// CreatedBy `validate:"required"`
+	if x.CreatedBy == "" {
+		errs = append(errs, govalid.ValidationError{Field: "CreatedBy", StructField: "CreatedBy", Tag: "required", Value: x.CreatedBy, Err: errors.New(" field is required;")})
+	}
+
+	return errs
+}
+
+// Validate validates stamp like govalid.ValidateStruct, without reflection
+func (x *stamp) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// UpdatedBy `validate:"required"`
+	if x.UpdatedBy == "" {
+		errs = append(errs, govalid.ValidationError{Field: "UpdatedBy", StructField: "UpdatedBy", Tag: "required", Value: x.UpdatedBy, Err: errors.New(" field is required;")})
+	}
+
+	return errs
+}
+
+// Validate validates Place like govalid.ValidateStruct, without reflection
+func (x *Place) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Audit
+	errs = append(errs, govalid.ValidatePartial(x, "Audit")...)
+
+	// stamp
+	errs = append(errs, govalid.ValidatePartial(x, "stamp")...)
+
+	// Title `validate:"required"`
+	if x.Title == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Title", StructField: "Title", Tag: "required", Value: x.Title, Err: errors.New(" field is required;")})
+	}
+
+	// Location
+	errs = append(errs, govalid.ValidatePartial(x, "Location")...)
+
+	// Backup
+	errs = append(errs, govalid.ValidatePartial(x, "Backup")...)
+
+	return errs
+}
+
+// Validate validates LineItem like govalid.ValidateStruct, without reflection
+func (x *LineItem) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Name `validate:"required"`
+	if x.Name == "" {
+		errs = append(errs, govalid.ValidationError{Field: "Name", StructField: "Name", Tag: "required", Value: x.Name, Err: errors.New(" field is required;")})
+	}
+
+	// Price `validate:"min=1"`
+	if x.Price < 1 {
+		errs = append(errs, govalid.ValidationError{Field: "Price", StructField: "Price", Tag: "min=1", Value: x.Price, Err: errors.New(" must be greater than or equal to 1.000000; ")})
+	}
+
+	return errs
+}
+
+// Validate validates Invoice like govalid.ValidateStruct, without reflection
+func (x *Invoice) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Items `validate:"required,dive"`
+	errs = append(errs, govalid.ValidatePartial(x, "Items")...)
+
+	// Codes `validate:"dive,required"`
+	errs = append(errs, govalid.ValidatePartial(x, "Codes")...)
+
+	// Notes `validate:"dive,min=3"`
+	errs = append(errs, govalid.ValidatePartial(x, "Notes")...)
+
+	return errs
+}
+
+// Validate validates Items like govalid.ValidateStruct, without reflection
+func (x *Items) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Tags `validate:"minItems=2,maxItems=3,dive,min=3"`
+	errs = append(errs, govalid.ValidatePartial(x, "Tags")...)
+
+	// Scores `validate:"len=2"`
+	if len(x.Scores) != 2 {
+		errs = append(errs, govalid.ValidationError{Field: "Scores", StructField: "Scores", Tag: "len=2", Value: x.Scores, Err: fmt.Errorf(" must contain exactly 2 items, got %d", len(x.Scores))})
+	}
+
+	// Code `validate:"len=4"`
+	if len(x.Code) != 4 {
+		errs = append(errs, govalid.ValidationError{Field: "Code", StructField: "Code", Tag: "len=4", Value: x.Code, Err: errors.New(" must be exactly 4 characters long")})
+	}
+
+	// Count `validate:"minItems=1,len=1"`
+	errs = append(errs, govalid.ValidationError{Field: "Count", StructField: "Count", Tag: "minItems=1", Value: x.Count, Err: errors.New(" minItems only supports slices, arrays and maps")})
+	errs = append(errs, govalid.ValidationError{Field: "Count", StructField: "Count", Tag: "len=1", Value: x.Count, Err: errors.New(" len only supports strings, slices, arrays and maps")})
+
+	// Names `validate:"required,maxItems=2"`
+	if len(x.Names) == 0 {
+		errs = append(errs, govalid.ValidationError{Field: "Names", StructField: "Names", Tag: "required", Value: x.Names, Err: errors.New(" field is required;")})
+	}
+	if len(x.Names) > 2 {
+		errs = append(errs, govalid.ValidationError{Field: "Names", StructField: "Names", Tag: "maxItems=2", Value: x.Names, Err: fmt.Errorf(" must contain at most 2 items, got %d", len(x.Names))})
+	}
+
+	// Labels `validate:"required,minItems=2"`
+	errs = append(errs, govalid.ValidatePartial(x, "Labels")...)
+
+	// Pair `validate:"required,len=2"`
+	errs = append(errs, govalid.ValidatePartial(x, "Pair")...)
+
+	return errs
+}
+
+// Validate validates Pointers like govalid.ValidateStruct, without reflection
+func (x *Pointers) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Name `validate:"required,min=3"`
+	errs = append(errs, govalid.ValidatePartial(x, "Name")...)
+
+	// Any `validate:"required"`
+	errs = append(errs, govalid.ValidatePartial(x, "Any")...)
+
+	// Shape
+	errs = append(errs, govalid.ValidatePartial(x, "Shape")...)
+
+	return errs
+}
+
+// Validate validates Optional like govalid.ValidateStruct, without reflection
+func (x *Optional) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Nick `validate:"omitempty,min=3"`
+	errs = append(errs, govalid.ValidatePartial(x, "Nick")...)
+
+	// Age `validate:"omitnil,min=18"`
+	errs = append(errs, govalid.ValidatePartial(x, "Age")...)
+
+	// Email `validate:"omitnil,email"`
+	errs = append(errs, govalid.ValidatePartial(x, "Email")...)
+
+	// Codes `validate:"omitempty,dive,len=2"`
+	errs = append(errs, govalid.ValidatePartial(x, "Codes")...)
+
+	return errs
+}
+
+// Validate validates Groups like govalid.ValidateStruct, without reflection
+func (x *Groups) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Contact `validate:"email|regex=username"`
+	errs = append(errs, govalid.ValidatePartial(x, "Contact")...)
+
+	// Handle `validate:"!email"`
+	errs = append(errs, govalid.ValidatePartial(x, "Handle")...)
+
+	// Zip `validate:"regex='^[0-9]{5}$'"`
+	errs = append(errs, govalid.ValidatePartial(x, "Zip")...)
+
+	// Size `validate:"!min=10|max=3,required"`
+	errs = append(errs, govalid.ValidatePartial(x, "Size")...)
+
+	// Alias `validate:"gen_handle"`
+	errs = append(errs, govalid.ValidatePartial(x, "Alias")...)
+
+	return errs
+}
+
+// Validate validates Booking like govalid.ValidateStruct, without reflection
+func (x *Booking) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Window
+	errs = append(errs, govalid.ValidatePartial(x, "Window")...)
+
+	// Start `validate:"gtcsfield=Window.Start"`
+	errs = append(errs, govalid.ValidatePartial(x, "Start")...)
+
+	// End `validate:"gtfield=Start,ltecsfield=Window.Start"`
+	errs = append(errs, govalid.ValidatePartial(x, "End")...)
+
+	// Seats `validate:"required_if=Plan team"`
+	errs = append(errs, govalid.ValidatePartial(x, "Seats")...)
+
+	// Coupon `validate:"excluded_if=Plan free"`
+	errs = append(errs, govalid.ValidatePartial(x, "Coupon")...)
+
+	// Note `validate:"required_unless=Plan free"`
+	errs = append(errs, govalid.ValidatePartial(x, "Note")...)
+
+	// Email `validate:"required_without=Phone"`
+	errs = append(errs, govalid.ValidatePartial(x, "Email")...)
+
+	// Phone `validate:"excluded_unless=Plan team"`
+	errs = append(errs, govalid.ValidatePartial(x, "Phone")...)
+
+	return errs
+}
+
+// Validate validates Conditional like govalid.ValidateStruct, without reflection
+func (x *Conditional) Validate() []govalid.ValidationError {
+	var errs []govalid.ValidationError
+
+	// Guardian `validate_if:"Age<18,required"`
+	errs = append(errs, govalid.ValidatePartial(x, "Guardian")...)
+
+	// State `validate_if:"Country in (US;CA),required,len=2"`
+	errs = append(errs, govalid.ValidatePartial(x, "State")...)
+
+	// Tax `validate_if:"Country!=ID && Age>=21,required"`
+	errs = append(errs, govalid.ValidatePartial(x, "Tax")...)
+
+	return errs
+}
@@ -0,0 +1,164 @@
+// Package gendata holds the structs used to check that the code generated
+// by govalid-gen returns the same errors as govalid.ValidateStruct.
+package gendata
+
+import (
+	"errors"
+
+	govalid "github.com/harrysan/govalid/validator"
+)
+
+//go:generate go run ../../cmd/govalid-gen
+
+type Address struct {
+	City    string `validate:"required"`
+	ZipCode string `validate:"required,min=5"`
+}
+
+type User struct {
+	Name     string            `validate:"required,min=3,max=10"`
+	Age      int               `validate:"min=18,max=99"`
+	Email    string            `validate:"required,email"`
+	Username string            `validate:"required,regex=username"`
+	Phone    string            `validate:"regex=missing"`
+	IsActive bool              `validate:"isTrue"`
+	IsBanned bool              `validate:"isFalse"`
+	Reason   string            `validate_if:"IsActive=true,required"`
+	Address  Address           `validate:"struct"`
+	Tags     map[string]string `validate:"maps,keys=required;min=3,values=required;min=5"`
+}
+
+type Numbers struct {
+	Int     int     `validate:"required,min=1,max=10"`
+	Int32   int32   `validate:"min=1,max=10"`
+	Int64   int64   `validate:"required,min=1,max=10"`
+	Float32 float32 `validate:"min=1.5,max=10.25"`
+	Float64 float64 `validate:"min=1.5,max=10.25"`
+	Uint    uint    `validate:"min=1,max=10"`
+	Bool    bool    `validate:"required,min=1,email"`
+}
+
+type Slices struct {
+	Names  []string  `validate:"slice,required,min=3,max=5"`
+	Ages   []int     `validate:"slice,max=30"`
+	Scores []float64 `validate:"min=0.5"`
+	Emails []string  `validate:"slice,email"`
+	IDs    []int     `validate:"email"`
+	Flags  []bool    `validate:"slice,isTrue"`
+}
+
+type Kinds struct {
+	NotSlice  string `validate:"slice"`
+	NotMap    string `validate:"maps"`
+	NotStruct int    `validate:"struct"`
+	Message   int    `validate:"min=18" error_message:"Age must be at least 18"`
+	Regex     string `validate:"regex=email" error_message:"Invalid email format"`
+	Bail      string `validate:"bail,required,min=3,email"`
+	Custom    int    `validate:"custom=isOdd"`
+	Compare   int    `validate:"gtfield=Custom"`
+}
+
+type Period struct {
+	Start int `validate:"min=1"`
+	End   int
+}
+
+func (p Period) ValidateWith(r *govalid.Reporter) {
+	if p.End <= p.Start {
+		r.Report("End", "gtfield=Start", errors.New(" must be greater than Start"))
+	}
+}
+
+type Geo struct {
+	Lat float64 `validate:"min=-90,max=90"`
+	Lng float64 `validate:"min=-180,max=180"`
+}
+
+type Location struct {
+	Name string `validate:"required"`
+	Geo  Geo
+}
+
+type Audit struct {
+	CreatedBy string `validate:"required"`
+}
+
+// stamp is embedded with an unexported type, its exported fields are promoted
+type stamp struct {
+	UpdatedBy string `validate:"required"`
+}
+
+type Place struct {
+	Audit
+	stamp
+	Title    string `validate:"required"`
+	Location Location
+	Backup   *Location
+}
+
+type LineItem struct {
+	Name  string  `validate:"required"`
+	Price float64 `validate:"min=1"`
+}
+
+type Invoice struct {
+	Items []LineItem `validate:"required,dive"`
+	Codes [2]string  `validate:"dive,required"`
+	Notes []string   `validate:"dive,min=3"`
+}
+
+type Items struct {
+	Tags   []string       `validate:"minItems=2,maxItems=3,dive,min=3"`
+	Scores []int          `validate:"len=2"`
+	Code   string         `validate:"len=4"`
+	Count  int            `validate:"minItems=1,len=1"`
+	Names  []string       `validate:"required,maxItems=2"`
+	Labels map[string]int `validate:"required,minItems=2"`
+	Pair   [2]int         `validate:"required,len=2"`
+}
+
+type Pointers struct {
+	Name   *string `validate:"required,min=3"`
+	Any    any     `validate:"required"`
+	Shape  interface{}
+	hidden string `validate:"required"`
+}
+
+type Optional struct {
+	Nick  string   `validate:"omitempty,min=3"`
+	Age   *int     `validate:"omitnil,min=18"`
+	Email *string  `validate:"omitnil,email"`
+	Codes []string `validate:"omitempty,dive,len=2"`
+}
+
+type Groups struct {
+	Contact string `validate:"email|regex=username"`
+	Handle  string `validate:"!email"`
+	Zip     string `validate:"regex='^[0-9]{5}$'"`
+	Size    int    `validate:"!min=10|max=3,required"`
+	Alias   string `validate:"gen_handle"` // registered by the tests
+}
+
+type Window struct {
+	Start int
+}
+
+type Booking struct {
+	Window Window
+	Start  int `validate:"gtcsfield=Window.Start"`
+	End    int `validate:"gtfield=Start,ltecsfield=Window.Start"`
+	Plan   string
+	Seats  int    `validate:"required_if=Plan team"`
+	Coupon string `validate:"excluded_if=Plan free"`
+	Note   string `validate:"required_unless=Plan free"`
+	Email  string `validate:"required_without=Phone"`
+	Phone  string `validate:"excluded_unless=Plan team"`
+}
+
+type Conditional struct {
+	Age      int
+	Country  string
+	Guardian string `validate_if:"Age<18,required"`
+	State    string `validate_if:"Country in (US;CA),required,len=2"`
+	Tax      string `validate_if:"Country!=ID && Age>=21,required"`
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Account struct {
+	Password string            `validate:"password"`
+	Recovery *string           `validate:"password"`
+	Nick     string            `validate:"bail,handle"`
+	Extras   map[string]string `validate:"values=(handle)"`
+}
+
+func newAliasValidator(t *testing.T) *govalid.Validator {
+	v := govalid.New()
+	v.AddOrUpdateRegexRule("strong_password", `[0-9]`)
+	assert.NoError(t, v.RegisterAlias("password", "required,min=8,max=64,regex=strong_password"))
+	assert.NoError(t, v.RegisterAlias("handle", "name,regex='^[a-z]+$'"))
+	assert.NoError(t, v.RegisterAlias("name", "required,min=3"))
+	return v
+}
+
+func TestRegisterAlias(t *testing.T) {
+	v := newAliasValidator(t)
+
+	recovery := "recovery1"
+	valid := Account{Password: "secret123", Recovery: &recovery, Nick: "gopher", Extras: map[string]string{"a": "abc"}}
+	assert.Empty(t, v.ValidateStruct(valid))
+
+	invalid := Account{Password: "short", Nick: "Go", Extras: map[string]string{"a": "X1"}}
+	errs := v.ValidateStruct(invalid)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Password", "Password", "Recovery", "Nick", "Extras[a]", "Extras[a]"}, errorFields(errs))
+
+	// errors report the alias used in the tag and the rule that failed
+	assert.Equal(t, "min=8", errs[0].Tag)
+	assert.Equal(t, "password", errs[0].Alias)
+	assert.Equal(t, "regex=strong_password", errs[1].Tag)
+	assert.Equal(t, "required", errs[2].Tag)
+	assert.Equal(t, "password", errs[2].Alias)
+	assert.Equal(t, "min=3", errs[3].Tag)
+	assert.Equal(t, "handle", errs[3].Alias)
+	assert.Equal(t, "regex='^[a-z]+$'", errs[5].Tag)
+	assert.Equal(t, "handle", errs[5].Alias)
+	assert.Equal(t, "Field 'Password' failed validation 'min=8' of alias 'password' :  must be greater than or equal to 8; ", errs[0].Error())
+}
+
+func TestRegisterAliasErrors(t *testing.T) {
+	v := newAliasValidator(t)
+
+	assert.EqualError(t, v.RegisterAlias("password", "required"), "rule already exists: password")
+	assert.EqualError(t, v.RegisterAlias("min", "required"), "rule already exists: min")
+	assert.EqualError(t, v.RegisterCustomRule("handle", func(field string, value any) error { return nil }), "rule already exists: handle")
+	assert.EqualError(t, v.RegisterAlias("quoted", "regex='^[a-z]"), "invalid alias quoted: unterminated quote at column 7")
+
+	// aliases may refer to aliases registered later, cycles are refused
+	assert.NoError(t, v.RegisterAlias("a", "b,min=1"))
+	assert.NoError(t, v.RegisterAlias("b", "values=(c)"))
+	assert.EqualError(t, v.RegisterAlias("c", "required,a"), "alias cycle: c -> a -> b -> c")
+
+	type Params struct {
+		Password string `validate:"required,password=1"`
+	}
+	errs := v.ValidateStruct(Params{})
+	var syntaxErr *govalid.TagSyntaxError
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, `alias "password" takes no parameter`, syntaxErr.Msg)
+	assert.Equal(t, 10, syntaxErr.Column)
+
+	// rules of an alias are checked like the rules of the tag using it
+	strict := govalid.New(govalid.WithStrictTags())
+	assert.NoError(t, strict.RegisterAlias("typo", "required,requried"))
+	type Typo struct {
+		Name string `validate:"min=1,typo"`
+	}
+	errs = strict.ValidateStruct(Typo{})
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, `unknown rule "requried" in alias "typo"`, syntaxErr.Msg)
+	assert.Equal(t, 7, syntaxErr.Column)
+}
+
+func TestRegisterAliasAfterUse(t *testing.T) {
+	v := govalid.New()
+	type Later struct {
+		Code string `validate:"code"`
+	}
+
+	// unknown rules are ignored until the alias is registered
+	assert.Empty(t, v.ValidateStruct(Later{}))
+	assert.NoError(t, v.RegisterAlias("code", "required,len=4"))
+	assert.Equal(t, []string{"Code", "Code"}, errorFields(v.ValidateStruct(Later{})))
+	assert.Len(t, v.ValidateVar("AB", "code"), 1)
+}
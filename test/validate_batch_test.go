@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Record struct {
+	ID    int    `validate:"min=1"`
+	Email string `validate:"required,email"`
+}
+
+func records(n int, invalid ...int) []Record {
+	items := make([]Record, n)
+	for i := range items {
+		items[i] = Record{ID: i + 1, Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	for _, i := range invalid {
+		items[i].Email = "invalid_email"
+	}
+	return items
+}
+
+func TestValidateAll(t *testing.T) {
+	items := records(1000, 7, 3, 512)
+
+	results, err := govalid.ValidateAll(items, govalid.BatchOptions{Workers: 8})
+	assert.NoError(t, err)
+	fmt.Println("Validation failed:", results)
+
+	var indexes []int
+	for _, r := range results {
+		indexes = append(indexes, r.Index)
+		assert.Len(t, r.Errors, 1)
+	}
+	assert.Equal(t, []int{3, 7, 512}, indexes)
+}
+
+func TestValidateAllFailFast(t *testing.T) {
+	items := records(1000, 900, 250, 251)
+
+	for i := 0; i < 20; i++ {
+		results, err := govalid.ValidateAll(items, govalid.BatchOptions{Workers: 4, FailFast: true})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, 250, results[0].Index)
+	}
+}
+
+func TestValidateAllPointers(t *testing.T) {
+	items := []*Record{{ID: 1, Email: "a@example.com"}, nil, {ID: 0, Email: "b@example.com"}}
+
+	results, err := govalid.ValidateAll(items, govalid.BatchOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 1, results[0].Index)
+	assert.Equal(t, 2, results[1].Index)
+}
+
+func TestValidateAllInvalidInput(t *testing.T) {
+	_, err := govalid.ValidateAll(Record{}, govalid.BatchOptions{})
+
+	var invalid *govalid.InvalidValidationError
+	assert.True(t, errors.As(err, &invalid))
+}
+
+func TestValidateAllConcurrentRegistration(t *testing.T) {
+	v := govalid.New()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = v.RegisterCustomRule(fmt.Sprintf("rule%d", i), func(string, any) error { return nil })
+		}
+	}()
+
+	_, err := v.ValidateAll(records(1000), govalid.BatchOptions{Workers: 4})
+	assert.NoError(t, err)
+	<-done
+}
+
+func TestValidateAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := govalid.ValidateAllCtx(ctx, records(1000, 3), govalid.BatchOptions{Workers: 4})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/harrysan/govalid/test/gendata"
+	govalid "github.com/harrysan/govalid/validator"
+)
+
+type UserBench struct {
+	Name     string            `validate:"required,min=3,max=32"`
+	Age      int               `validate:"min=18,max=99"`
+	Email    string            `validate:"required,email"`
+	Username string            `validate:"required,regex=username"`
+	Address  Address           `validate:"struct"`
+	Tags     map[string]string `validate:"maps,keys=required;min=3,values=required;min=5"`
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	user := UserBench{
+		Name:     "John",
+		Age:      30,
+		Email:    "john@doe.com",
+		Username: "john_doe",
+		Address:  Address{City: "Jakarta", ZipCode: "12345"},
+		Tags:     map[string]string{"env": "production"},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		govalid.ValidateStruct(user)
+	}
+}
+
+func BenchmarkValidateStructFail(b *testing.B) {
+	user := UserBench{
+		Name:     "Jo",
+		Age:      17,
+		Email:    "invalid_email",
+		Username: "j",
+		Tags:     map[string]string{"e": "prod"},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		govalid.ValidateStruct(user)
+	}
+}
+
+func BenchmarkGeneratedValidate(b *testing.B) {
+	numbers := gendata.Numbers{Int: 5, Int32: 5, Int64: 5, Float32: 5, Float64: 5, Uint: 5}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		numbers.Validate()
+	}
+}
+
+func BenchmarkReflectiveValidate(b *testing.B) {
+	numbers := gendata.Numbers{Int: 5, Int32: 5, Int64: 5, Float32: 5, Float64: 5, Uint: 5}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		govalid.ValidateStruct(numbers)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Contact struct {
+	Reach  string            `validate:"required,regex=email|regex=phone_number"`
+	Handle string            `validate:"!regex='^admin',min=3|len=0"`
+	Codes  []string          `validate:"dive,len=2|len=3"`
+	Notes  map[string]string `validate:"values=(!required|min=5)"`
+	Alias  *string           `validate:"!required"`
+	Draft  string            `validate:"!regex=slug"`
+}
+
+func TestValidationOr(t *testing.T) {
+	valid := []Contact{
+		{Reach: "gopher@example.com", Handle: "Go_pher", Codes: []string{"ab", "abc"}},
+		{Reach: "+628123456789", Handle: "", Notes: map[string]string{"a": "", "b": "hello"}},
+	}
+	for _, contact := range valid {
+		assert.Empty(t, govalid.ValidateStruct(contact))
+	}
+
+	alias := "gopher"
+	invalid := Contact{Reach: "gopher", Handle: "admin", Codes: []string{"a"}, Notes: map[string]string{"a": "hey"}, Alias: &alias, Draft: "my-post"}
+	errs := govalid.ValidateStruct(invalid)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Reach", "Handle", "Codes[0]", "Notes[a]", "Alias", "Draft"}, errorFields(errs))
+
+	// a failing OR group is a single error listing every alternative
+	assert.Equal(t, "regex=email|regex=phone_number", errs[0].Tag)
+	assert.Equal(t, " must pass one of regex=email (gopher does not match the required pattern;) | regex=phone_number (gopher does not match the required pattern;);", errs[0].Err.Error())
+
+	var alternatives *govalid.AlternativesError
+	assert.True(t, errors.As(errs[0], &alternatives))
+	assert.Equal(t, []string{"regex=email", "regex=phone_number"}, alternatives.Tags)
+	assert.Len(t, alternatives.Errs, 2)
+
+	assert.Equal(t, "!regex='^admin'", errs[1].Tag)
+	assert.Equal(t, " must not pass regex='^admin';", errs[1].Err.Error())
+	assert.Equal(t, "!required|min=5", errs[3].Tag)
+	assert.Equal(t, "!required", errs[4].Tag)
+	assert.Equal(t, " must not pass required;", errs[4].Err.Error())
+	assert.Equal(t, " must not pass regex=slug;", errs[5].Err.Error())
+}
+
+func TestValidationOrTags(t *testing.T) {
+	v := govalid.New()
+	assert.NoError(t, v.RegisterAlias("contact", "regex=email|regex=phone_number"))
+	assert.NoError(t, v.RegisterAlias("short", "max=3"))
+
+	type Aliased struct {
+		Reach string `validate:"contact"`
+		Name  string `validate:"!short"`
+		Code  string `validate:"short|len=5"`
+	}
+	errs := v.ValidateStruct(Aliased{Reach: "x", Name: "abc", Code: "abcd"})
+	assert.Equal(t, []string{"Reach", "Name", "Code"}, errorFields(errs))
+	assert.Equal(t, "contact", errs[0].Alias)
+	assert.Equal(t, "!max=3", errs[1].Tag)
+	assert.Equal(t, "short", errs[1].Alias)
+	assert.Equal(t, "short|len=5", errs[2].Tag)
+
+	type BadDive struct {
+		Dive []string `validate:"dive|required"`
+	}
+	errs = v.ValidateStruct(BadDive{})
+	var syntaxErr *govalid.TagSyntaxError
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, "dive cannot be used in an OR group", syntaxErr.Msg)
+	assert.Equal(t, 1, syntaxErr.Column)
+
+	type BadBail struct {
+		Bail string `validate:"required,!bail"`
+	}
+	errs = v.ValidateStruct(BadBail{})
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, "bail cannot be negated", syntaxErr.Msg)
+	assert.Equal(t, 10, syntaxErr.Column)
+
+	type BadEmpty struct {
+		Empty string `validate:"required|"`
+	}
+	errs = v.ValidateStruct(BadEmpty{})
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, "empty alternative", syntaxErr.Msg)
+	assert.Equal(t, 10, syntaxErr.Column)
+}
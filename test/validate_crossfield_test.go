@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Credentials struct {
+	Email string
+}
+
+type Booking struct {
+	Account  Credentials
+	Password string
+	Confirm  string        `validate:"eqfield=Password"`
+	Previous string        `validate:"nefield=Password"`
+	Start    time.Time     `validate:"required"`
+	End      time.Time     `validate:"gtfield=Start"`
+	MinStay  time.Duration `validate:"min=0"`
+	MaxStay  time.Duration `validate:"gtefield=MinStay"`
+	Guests   int           `validate:"ltefield=Rooms.Max"`
+	Rooms    *RoomLimit
+	Contacts []BookingContact `validate:"dive"`
+	Backups  []string         `validate:"dive,nefield=Account.Email"`
+}
+
+type RoomLimit struct {
+	Max int
+}
+
+type BookingContact struct {
+	Email string `validate:"eqcsfield=Account.Email|eqcsfield=Password"`
+	Phone string
+}
+
+func TestCrossField(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	booking := Booking{
+		Account:  Credentials{Email: "me@example.com"},
+		Password: "secret",
+		Confirm:  "secret",
+		Previous: "old",
+		Start:    start,
+		End:      start.Add(48 * time.Hour),
+		MinStay:  time.Hour,
+		MaxStay:  time.Hour,
+		Guests:   2,
+		Rooms:    &RoomLimit{Max: 2},
+		Contacts: []BookingContact{{Email: "me@example.com"}},
+		Backups:  []string{"other@example.com"},
+	}
+	assert.Empty(t, govalid.ValidateStruct(booking))
+
+	booking.Confirm = "Secret"
+	booking.Previous = "secret"
+	booking.End = start.Add(-time.Hour)
+	booking.MaxStay = time.Minute
+	booking.Guests = 3
+	booking.Contacts = []BookingContact{{Email: "you@example.com"}}
+	booking.Backups = []string{"me@example.com"}
+
+	errs := govalid.ValidateStruct(booking)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Confirm", "Previous", "End", "MaxStay", "Guests", "Contacts[0].Email", "Backups[0]"}, errorFields(errs))
+
+	// errors name the compared field instead of showing its value
+	assert.Equal(t, " must be equal to Password", errs[0].Err.Error())
+	assert.Equal(t, " must be greater than Start", errs[2].Err.Error())
+	assert.Equal(t, " must be less than or equal to Rooms.Max", errs[4].Err.Error())
+	assert.Equal(t, "eqcsfield=Account.Email|eqcsfield=Password", errs[5].Tag)
+
+	// a missing field to compare with fails the rule
+	booking.Rooms = nil
+	errs = govalid.ValidatePartial(booking, "Guests")
+	assert.Equal(t, " cannot compare with Rooms.Max, it is missing", errs[0].Err.Error())
+}
+
+func TestCrossFieldTagErrors(t *testing.T) {
+	type Mismatch struct {
+		Count int
+		Name  string `validate:"required,eqfield=Count"`
+	}
+	type Missing struct {
+		Name string `validate:"eqfield=Nmae"`
+	}
+	type NoParam struct {
+		Name string `validate:"gtfield"`
+	}
+	type TimeVsInt struct {
+		At    time.Time
+		Count int `validate:"ltfield=At"`
+	}
+
+	tests := []struct {
+		data   any
+		column int
+		msg    string
+	}{
+		{Mismatch{}, 10, "eqfield cannot compare string with int"},
+		{Missing{}, 1, `field "Nmae" not found in main.Missing`},
+		{NoParam{}, 1, "gtfield requires the name of a field"},
+		{TimeVsInt{}, 1, "ltfield cannot compare int with time.Time"},
+	}
+	for _, tt := range tests {
+		errs := govalid.ValidateStruct(tt.data)
+		fmt.Println("Validation failed:", errs)
+		assert.Len(t, errs, 1)
+
+		var syntaxErr *govalid.TagSyntaxError
+		assert.True(t, errors.As(errs[0], &syntaxErr))
+		assert.Equal(t, tt.column, syntaxErr.Column)
+		assert.Equal(t, tt.msg, syntaxErr.Msg)
+	}
+
+	// paths from the root are resolved while validating
+	type Line struct {
+		Qty int `validate:"ltecsfield=Limit"`
+	}
+	type Order struct {
+		Limit string
+		Lines []Line `validate:"dive"`
+	}
+	errs := govalid.ValidateStruct(Order{Lines: []Line{{Qty: 1}}})
+	var syntaxErr *govalid.TagSyntaxError
+	assert.Equal(t, []string{"Lines[0].Qty"}, errorFields(errs))
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, "ltecsfield cannot compare int with string", syntaxErr.Msg)
+}
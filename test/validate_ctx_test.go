@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type UserCtx struct {
+	Username string `validate:"required,custom=uniqueUsername"`
+	Email    string `validate:"required,email"`
+}
+
+func newCtxValidator(t *testing.T) *govalid.Validator {
+	v := govalid.New()
+	err := v.RegisterCustomRuleCtx("uniqueUsername", func(ctx context.Context, field string, value any) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+		if value == "taken" {
+			return fmt.Errorf("%s is already taken", field)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	return v
+}
+
+func TestValidateStructCtx(t *testing.T) {
+	v := newCtxValidator(t)
+
+	errs := v.ValidateStructCtx(context.Background(), UserCtx{Username: "taken", Email: "taken@example.com"})
+	fmt.Println("Validation failed:", errs)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "custom=uniqueUsername", errs[0].Tag)
+
+	errs = v.ValidateStructCtx(context.Background(), UserCtx{Username: "free", Email: "free@example.com"})
+	assert.Empty(t, errs)
+}
+
+func TestValidateStructCtxCancelled(t *testing.T) {
+	v := newCtxValidator(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := v.ValidateStructCtx(ctx, UserCtx{Username: "free", Email: "invalid_email"})
+	fmt.Println("Validation failed:", errs)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+
+	err := v.ValidateCtx(ctx, &UserCtx{})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestValidateCustomRuleBareName(t *testing.T) {
+	v := govalid.New()
+	assert.NoError(t, v.RegisterCustomRule("isEven", func(field string, value any) error {
+		if value.(int)%2 != 0 {
+			return fmt.Errorf("%s must be an even number", field)
+		}
+		return nil
+	}))
+
+	type Data struct {
+		Number int `validate:"isEven"`
+	}
+
+	errs := v.ValidateStruct(Data{Number: 3})
+	assert.Len(t, errs, 1)
+	assert.Empty(t, v.ValidateStruct(Data{Number: 4}))
+}
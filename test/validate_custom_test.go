@@ -31,7 +31,7 @@ func TestCustomValidation(t *testing.T) {
 	data := Data{Number: 3} // Expect error
 	errs := govalid.ValidateStruct(data)
 
-	assert.Nil(t, errs)
+	assert.NotNil(t, errs)
 
 	if len(errs) > 0 {
 		fmt.Println("Validation Errors:")
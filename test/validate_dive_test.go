@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type LineItem struct {
+	Name  string  `validate:"required"`
+	Price float64 `validate:"min=1"`
+}
+
+type Invoice struct {
+	Items  []LineItem  `validate:"required,dive"`
+	Refs   []*LineItem `validate:"dive"`
+	Codes  [3]string   `validate:"dive,required,min=2"`
+	Matrix [][]int     `validate:"dive,required,dive,max=9"`
+	Notes  []string    `validate:"dive,min=3" error_message:"note is too short"`
+	Extra  []LineItem  // no dive, elements are not validated
+}
+
+func TestValidateDive(t *testing.T) {
+	invoice := Invoice{
+		Items: []LineItem{
+			{Name: "Pen", Price: 2},
+			{Name: "", Price: 0.5},
+		},
+		Refs:   []*LineItem{nil, {Name: "Ink"}},
+		Codes:  [3]string{"AB", "", "C"},
+		Matrix: [][]int{{1, 2}, {}, {10}},
+		Notes:  []string{"ok", "fine"},
+		Extra:  []LineItem{{}},
+	}
+
+	errs := govalid.ValidateStruct(invoice)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{
+		"Items[1].Name", "Items[1].Price",
+		"Refs[1].Price",
+		"Codes[1]", "Codes[1]", "Codes[2]",
+		"Matrix[1]", "Matrix[2][0]",
+		"Notes[0]",
+	}, errorFields(errs))
+	assert.Equal(t, "note is too short", errs[len(errs)-1].Err.Error())
+	assert.Equal(t, "ok", errs[len(errs)-1].Value)
+
+	// rules before dive apply to the collection itself
+	errs = govalid.ValidateStruct(Invoice{Codes: [3]string{"AB", "CD", "EF"}})
+	assert.Equal(t, []string{"Items"}, errorFields(errs))
+}
+
+func TestValidateDivePartial(t *testing.T) {
+	invoice := Invoice{
+		Items: []LineItem{{Name: "", Price: 2}, {Name: "", Price: 0}},
+		Codes: [3]string{"AB", "CD", "EF"},
+	}
+
+	errs := govalid.ValidatePartial(invoice, "Items[1].Price")
+	assert.Equal(t, []string{"Items[1].Price"}, errorFields(errs))
+
+	errs = govalid.ValidateExcept(invoice, "Items[0]")
+	assert.Equal(t, []string{"Items[1].Name", "Items[1].Price"}, errorFields(errs))
+}
+
+func TestValidateVarDive(t *testing.T) {
+	errs := govalid.ValidateVar([]string{"go", ""}, "required,dive,required")
+	assert.Equal(t, []string{"[1]"}, errorFields(errs))
+
+	errs = govalid.ValidateVar("text", "dive,required")
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "dive requires a slice, an array or a map")
+}
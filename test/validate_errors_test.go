@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type UserBadIf struct {
+	IsActive bool
+	Reason   string `validate_if:"IsActive"`
+}
+
+type UserUnknownIf struct {
+	Reason string `validate_if:"IsActive=true,required"`
+}
+
+func TestValidateNonStruct(t *testing.T) {
+	var invalid *govalid.InvalidValidationError
+
+	for _, input := range []any{nil, 42, "string", (*UserStruct)(nil), new(int)} {
+		err := govalid.Validate(input)
+		fmt.Println("Validation Error:", err)
+		assert.True(t, errors.As(err, &invalid), "input %#v", input)
+	}
+
+	errs := govalid.ValidateStruct(42)
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.As(errs[0].Err, &invalid))
+}
+
+func TestValidatePointer(t *testing.T) {
+	user := UserStruct{Name: "John", Age: 20, Address: Address{City: "Jakarta", ZipCode: "12345"}}
+
+	assert.NoError(t, govalid.Validate(&user))
+	assert.Empty(t, govalid.ValidateStruct(&user))
+
+	user.Name = ""
+	err := govalid.Validate(&user)
+
+	var errs govalid.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Name", errs[0].Field)
+}
+
+func TestValidateTagSyntaxError(t *testing.T) {
+	var tagErr *govalid.TagSyntaxError
+
+	for _, input := range []any{UserBadIf{}, UserUnknownIf{}} {
+		err := govalid.Validate(input)
+		fmt.Println("Validation Error:", err)
+		assert.True(t, errors.As(err, &tagErr))
+		assert.Equal(t, "Reason", tagErr.Field)
+
+		errs := govalid.ValidateStruct(input)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Reason", errs[0].Field)
+	}
+}
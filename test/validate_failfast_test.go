@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type UserFailFast struct {
+	Name  string `validate:"required,min=3"`
+	Age   int    `validate:"min=18,max=99"`
+	Email string `validate:"required,email"`
+}
+
+type UserBail struct {
+	Name  string `validate:"bail,required,min=3"`
+	Email string `validate:"required,email"`
+}
+
+func TestValidateFailFast(t *testing.T) {
+	user := UserFailFast{Name: "", Age: 17, Email: ""}
+
+	full := govalid.New().ValidateStruct(user)
+	assert.Len(t, full, 5)
+
+	errs := govalid.New(govalid.WithFailFast()).ValidateStruct(user)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, full[:1], errs)
+
+	errs = govalid.New(govalid.WithMaxErrors(3)).ValidateStruct(user)
+	assert.Equal(t, full[:3], errs)
+
+	errs = govalid.New(govalid.WithMaxErrors(10)).ValidateStruct(user)
+	assert.Equal(t, full, errs)
+}
+
+func TestValidateBail(t *testing.T) {
+	user := UserBail{Name: "", Email: ""}
+
+	errs := govalid.ValidateStruct(user)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Name", "Email", "Email"}, errorFields(errs))
+	assert.Equal(t, "required", errs[0].Tag)
+
+	errs = govalid.ValidateVar("", "bail,required,min=3")
+	assert.Len(t, errs, 1)
+}
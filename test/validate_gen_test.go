@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harrysan/govalid/rules"
+	"github.com/harrysan/govalid/test/gendata"
+	"github.com/harrysan/govalid/test/gendata/binding"
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type generatedValidator interface {
+	Validate() []govalid.ValidationError
+}
+
+// genError drops the error type, generated and reflective errors only need
+// the same message
+type genError struct {
+	Field       string
+	StructField string
+	Tag         string
+	Value       any
+	Err         string
+}
+
+func genErrors(errs []govalid.ValidationError) []genError {
+	var out []genError
+	for _, e := range errs {
+		out = append(out, genError{Field: e.Field, StructField: e.StructField, Tag: e.Tag, Value: e.Value, Err: e.Err.Error()})
+	}
+	return out
+}
+
+func TestGeneratedMatchesReflection(t *testing.T) {
+	// the default Validator outlives the test, it keeps the alias of a previous run
+	_ = govalid.RegisterAlias("gen_handle", "required,min=3,regex=username")
+
+	age, email := 16, "invalid_email"
+	inputs := []generatedValidator{
+		&gendata.Address{},
+		&gendata.Address{City: "Jakarta", ZipCode: "123"},
+		&gendata.User{},
+		&gendata.User{
+			Name:     "Johnathan Doe",
+			Age:      120,
+			Email:    "invalid_email",
+			Username: "j",
+			IsBanned: true,
+			Address:  gendata.Address{ZipCode: "1"},
+			Tags:     map[string]string{"e": "prod"},
+		},
+		&gendata.User{
+			Name:     "John",
+			Age:      30,
+			Email:    "john@doe.com",
+			Username: "john_doe",
+			IsActive: true,
+			Reason:   "Reason",
+			Address:  gendata.Address{City: "Jakarta", ZipCode: "12345"},
+			Tags:     map[string]string{"env": "production"},
+		},
+		&gendata.Numbers{},
+		&gendata.Numbers{Int: 11, Int32: 11, Int64: 11, Float32: 10.3, Float64: 10.3, Uint: 11, Bool: true},
+		&gendata.Numbers{Int: 5, Int32: 5, Int64: 5, Float32: 5, Float64: 5, Uint: 5},
+		&gendata.Slices{},
+		&gendata.Slices{
+			Names:  []string{"John", "Do", "JD", "Johnathan"},
+			Ages:   []int{17, 31},
+			Scores: []float64{0.25, 1},
+			Emails: []string{"john@doe.com", "invalid_email"},
+			IDs:    []int{1, 2},
+			Flags:  []bool{false},
+		},
+		&gendata.Kinds{},
+		&gendata.Kinds{Message: 20, Regex: "john@doe.com", Bail: "jo", Custom: 3, Compare: 1},
+		&gendata.Kinds{Bail: "john"},
+		&gendata.Period{},
+		&gendata.Period{Start: 1, End: 2},
+		&gendata.Place{},
+		&gendata.Invoice{},
+		&gendata.Items{},
+		&gendata.Pointers{},
+		&gendata.Pointers{Name: new(string), Any: gendata.Geo{Lat: 100}, Shape: &gendata.Geo{Lng: 200}},
+		&gendata.Items{Tags: []string{"a", "b", "c", "d"}, Scores: []int{1, 2}, Code: "ABCD", Names: []string{"a", "b", "c"}, Labels: map[string]int{"a": 1}, Pair: [2]int{0, 1}},
+		&gendata.Invoice{Items: []gendata.LineItem{{Price: 2}, {Name: "Pen"}}, Codes: [2]string{"A"}, Notes: []string{"ok", "fine"}},
+		&gendata.Place{Location: gendata.Location{Geo: gendata.Geo{Lat: 91}}, Backup: &gendata.Location{}},
+		&gendata.Optional{},
+		&gendata.Optional{Nick: "Jo", Age: &age, Email: &email, Codes: []string{"ID", "USA"}},
+		&gendata.Groups{},
+		&gendata.Groups{Contact: "john@doe.com", Handle: "john@doe.com", Zip: "1234", Size: 12, Alias: "J!"},
+		&gendata.Groups{Contact: "john_doe", Handle: "john", Zip: "12345", Size: 2, Alias: "john_doe"},
+		&gendata.Booking{},
+		&gendata.Booking{Window: gendata.Window{Start: 5}, Start: 5, End: 6, Plan: "team", Coupon: "FREE"},
+		&gendata.Booking{Window: gendata.Window{Start: 5}, Start: 6, End: 5, Plan: "free", Coupon: "FREE", Phone: "123"},
+		&gendata.Conditional{},
+		&gendata.Conditional{Age: 30, Country: "US", State: "Texas"},
+		&gendata.Conditional{Age: 30, Country: "ID", Guardian: "Jane"},
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, genErrors(govalid.ValidateStruct(input)), genErrors(input.Validate()), "%#v", input)
+	}
+	assert.Equal(t, []string{"Nick", "Age", "Email", "Codes[1]"}, errorFields(inputs[len(inputs)-10].Validate()))
+	assert.Equal(t, []string{"Handle", "Zip", "Size", "Alias", "Alias"}, errorFields(inputs[len(inputs)-8].Validate()))
+	assert.Equal(t, []string{"Start", "End", "Seats", "Note", "Email"}, errorFields(inputs[len(inputs)-5].Validate()))
+	assert.Equal(t, []string{"Guardian"}, errorFields(inputs[len(inputs)-3].Validate()))
+	assert.Equal(t, []string{"State", "Tax"}, errorFields(inputs[len(inputs)-2].Validate()))
+}
+
+// the fallbacks of code generated for renamed tags read the same tags
+func TestGeneratedMatchesReflectionTagNames(t *testing.T) {
+	upper := func(field string, value any) error {
+		if s, _ := value.(string); s != strings.ToUpper(s) {
+			return fmt.Errorf(" %s must be upper case;", field)
+		}
+		return nil
+	}
+	rules.AddOrUpdateRegexRule("code", `^[A-Z]{3}$`)
+	// the generated Validator outlives the test, it keeps the rule of a previous run
+	_ = binding.GovalidValidator.RegisterCustomRule("upper", upper)
+
+	v := govalid.New(govalid.WithRegexRules(rules.DefaultRegexRules()), govalid.WithTagName("binding"), govalid.WithConditionTagName("binding_if"), govalid.WithMessageTagName("binding_message"))
+	assert.NoError(t, v.RegisterCustomRule("upper", upper))
+	inputs := []generatedValidator{
+		&binding.Profile{},
+		&binding.Profile{Name: "John", Nick: "J", Tags: map[string]string{"x": "y"}, Active: true, Emails: []string{"invalid_email"}, Codes: []string{"ABC", "abc"}, Team: "core"},
+		&binding.Profile{Name: "John", Nick: "JD", Tags: map[string]string{"env": "y"}, Codes: []string{"XYZ"}, Team: "CORE", Home: binding.Home{City: "Jakarta"}},
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, genErrors(v.ValidateStruct(input)), genErrors(input.Validate()), "%#v", input)
+	}
+	assert.Equal(t, []string{"Nick", "Tags[x]", "Reason", "Emails[0]", "Codes[1]", "Team", "Home.City"}, errorFields(inputs[1].Validate()))
+	assert.Empty(t, inputs[2].Validate())
+}
+
+func TestGeneratedIsUpToDate(t *testing.T) {
+	packages := []struct {
+		dir  string
+		args []string
+	}{
+		{"gendata", nil},
+		{"gendata/binding", []string{"-tag", "binding", "-cond-tag", "binding_if", "-message-tag", "binding_message"}},
+	}
+
+	for _, pkg := range packages {
+		output := filepath.Join(t.TempDir(), "govalid_gen.go")
+
+		args := append([]string{"run", "../cmd/govalid-gen", "-output", output}, pkg.args...)
+		cmd := exec.Command("go", append(args, "./"+pkg.dir)...)
+		out, err := cmd.CombinedOutput()
+		if !assert.NoError(t, err, string(out)) {
+			return
+		}
+
+		want, err := os.ReadFile(output)
+		assert.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(pkg.dir, "govalid_gen.go"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "run go generate ./test/%s", pkg.dir)
+	}
+}
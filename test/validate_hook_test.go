@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (p Period) ValidateWith(r *govalid.Reporter) {
+	if !p.End.After(p.Start) {
+		r.Report("End", "after=Start", errors.New(" must be after Start"))
+	}
+}
+
+type Order struct {
+	Total int    `validate:"min=1"`
+	Items []int  `validate:"slice,required"`
+	Dates Period `validate:"struct"`
+}
+
+func (o *Order) ValidateWith(r *govalid.Reporter) {
+	sum := 0
+	for _, item := range o.Items {
+		sum += item
+	}
+	if sum != o.Total {
+		r.Report("Total", "sum=Items", fmt.Errorf(" must equal the sum of items (%d)", sum))
+	}
+}
+
+func TestValidateStructValidator(t *testing.T) {
+	now := time.Now()
+	order := Order{
+		Total: 10,
+		Items: []int{3, 4},
+		Dates: Period{Start: now, End: now.Add(-time.Hour)},
+	}
+
+	errs := govalid.ValidateStruct(order)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Dates.End", "Total"}, errorFields(errs))
+	assert.Equal(t, "sum=Items", errs[1].Tag)
+	assert.Equal(t, 10, errs[1].Value)
+
+	order.Total = 7
+	order.Dates.End = now.Add(time.Hour)
+	assert.Empty(t, govalid.ValidateStruct(&order))
+}
+
+func TestValidateStructValidatorPartial(t *testing.T) {
+	order := Order{Total: 10, Items: []int{3}, Dates: Period{}}
+
+	errs := govalid.ValidatePartial(order, "Items")
+	assert.Empty(t, errs)
+
+	errs = govalid.ValidatePartial(order, "Total")
+	assert.Equal(t, []string{"Total"}, errorFields(errs))
+
+	errs = govalid.ValidateExcept(order, "Total")
+	assert.Equal(t, []string{"Dates.End"}, errorFields(errs))
+}
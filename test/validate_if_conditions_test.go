@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Billing struct {
+	Country string
+}
+
+type Payment struct {
+	Method     string
+	Amount     float64
+	Installs   int
+	Express    *bool
+	Billing    *Billing
+	Due        time.Duration
+	CardNumber string   `validate_if:"Method=card,required,len=16"`
+	Reference  string   `validate_if:"Method in (bank;'wire transfer') && Amount>1000,required,min=6"`
+	Reason     string   `validate_if:"Installs>=3 || Amount<0,required"`
+	VatID      string   `validate_if:"Billing.Country!=US,required" error_message:"VAT ID is required outside the US"`
+	Courier    string   `validate_if:"Express=true,required"`
+	Reminders  []string `validate_if:"Due>=24h,minItems=1,dive,email"`
+}
+
+func TestValidateIfConditions(t *testing.T) {
+	// no condition holds, a nil pointer on a path does not hold either
+	assert.Empty(t, govalid.ValidateStruct(Payment{Method: "cash", Amount: 5000, Installs: 1}))
+
+	express := true
+	payment := Payment{
+		Method:    "wire transfer",
+		Amount:    1500,
+		Installs:  3,
+		Express:   &express,
+		Billing:   &Billing{Country: "ID"},
+		Due:       48 * time.Hour,
+		Reference: "ref",
+		Reminders: []string{"me@example.com", "me"},
+	}
+	errs := govalid.ValidateStruct(payment)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Reference", "Reason", "VatID", "Courier", "Reminders[1]"}, errorFields(errs))
+	assert.Equal(t, "min=6", errs[0].Tag)
+	assert.Equal(t, "VAT ID is required outside the US", errs[2].Err.Error())
+
+	// every rule applies, not only required
+	card := Payment{Method: "card", CardNumber: "4111"}
+	errs = govalid.ValidateStruct(card)
+	assert.Equal(t, []string{"CardNumber"}, errorFields(errs))
+	assert.Equal(t, "len=16", errs[0].Tag)
+
+	// values compare as the type of the field
+	assert.Empty(t, govalid.ValidateStruct(Payment{Method: "bank", Amount: 1000.0}))
+	assert.Equal(t, []string{"Reference", "Reference"}, errorFields(govalid.ValidateStruct(Payment{Method: "bank", Amount: 1000.5})))
+	assert.Len(t, govalid.ValidateStruct(Payment{Amount: -1}), 1)
+	assert.Empty(t, govalid.ValidateStruct(Payment{Billing: &Billing{Country: "US"}}))
+}
+
+func TestValidateIfConditionErrors(t *testing.T) {
+	type BadValue struct {
+		Count int
+		Name  string `validate_if:"Count=many,required"`
+	}
+	type BadOrder struct {
+		Kind  string
+		Name  string `validate_if:"Count=1 && Kind>a,required"`
+		Count int
+	}
+	type BadField struct {
+		Name string `validate_if:"Owner.Name=x,required"`
+	}
+	type NoRules struct {
+		Active bool
+		Name   string `validate_if:"Active=true"`
+	}
+	type BadOperator struct {
+		Active bool
+		Name   string `validate_if:"Active true,required"`
+	}
+
+	tests := []struct {
+		data   any
+		column int
+		msg    string
+	}{
+		{BadValue{}, 1, `invalid value "many" for int`},
+		{BadOrder{}, 12, "> requires a number, a time or a duration, Kind is string"},
+		{BadField{}, 1, `field "Owner" not found in main.BadField`},
+		{NoRules{}, 12, "missing rules after the condition"},
+		{BadOperator{}, 8, "missing operator after Active"},
+	}
+	for _, tt := range tests {
+		errs := govalid.ValidateStruct(tt.data)
+		fmt.Println("Validation failed:", errs)
+		assert.Len(t, errs, 1)
+
+		var syntaxErr *govalid.TagSyntaxError
+		assert.True(t, errors.As(errs[0], &syntaxErr))
+		assert.Equal(t, tt.column, syntaxErr.Column)
+		assert.Equal(t, tt.msg, syntaxErr.Msg)
+	}
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 
 	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
 )
 
 type UserVIf struct {
@@ -45,18 +47,21 @@ func TestValidateIfMany(t *testing.T) {
 		user   UserVIf
 		hasErr bool
 	}{
-		{UserVIf{IsActive: true, Reason: ""}, true},            // Error karena Reason kosong
-		{UserVIf{IsActive: true, Reason: "Active"}, false},     // Valid
-		{UserVIf{IsActive: false, Reason: ""}, false},          // Tidak ada validasi karena IsActive=false
-		{UserVIf{IsActive: false, Reason: "Not Active"}, true}, // Tidak ada validasi karena IsActive=false
+		{UserVIf{IsActive: true, Reason: ""}, true},             // Error karena Reason kosong
+		{UserVIf{IsActive: true, Reason: "Active"}, false},      // Valid
+		{UserVIf{IsActive: false, Reason: ""}, false},           // Tidak ada validasi karena IsActive=false
+		{UserVIf{IsActive: false, Reason: "Not Active"}, false}, // Tidak ada validasi karena IsActive=false
 	}
 
 	for _, tt := range tests {
 		errs := govalid.ValidateStruct(tt.user)
 		fmt.Println(errs)
-		if (len(errs) > 0) != tt.hasErr {
+		// IsActive fails isTrue when false, only the errors of Reason count
+		hasErr := slices.Contains(errorFields(errs), "Reason")
+		assert.Equal(t, tt.hasErr, hasErr, "%+v", tt.user)
+		if hasErr != tt.hasErr {
 			// 	fmt.Println(errs)
-			fmt.Printf("Test failed for input %+v. Expected hasErr=%v, got %v.\n", tt.user, tt.hasErr, len(errs) > 0)
+			fmt.Printf("Test failed for input %+v. Expected hasErr=%v, got %v.\n", tt.user, tt.hasErr, hasErr)
 		}
 		fmt.Println("============================")
 	}
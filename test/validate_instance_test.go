@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type UserInstance struct {
+	Phone string `validate:"required,regex=phone_number"`
+}
+
+func TestValidatorInstanceRegexIsolation(t *testing.T) {
+	t.Parallel()
+
+	indonesia := govalid.New()
+	indonesia.AddOrUpdateRegexRule("phone_number", `^\+62[0-9]{9,13}$`)
+	global := govalid.New()
+
+	user := UserInstance{Phone: "+12345678901"}
+
+	errs := indonesia.ValidateStruct(user)
+	assert.Len(t, errs, 1)
+	fmt.Println("Validation Errors:", errs)
+
+	errs = global.ValidateStruct(user)
+	assert.Empty(t, errs)
+}
+
+func TestValidatorInstanceCustomRuleIsolation(t *testing.T) {
+	t.Parallel()
+
+	rule := func(field string, value any) error { return nil }
+
+	first := govalid.New()
+	second := govalid.New()
+
+	assert.NoError(t, first.RegisterCustomRule("isEven", rule))
+	assert.NoError(t, second.RegisterCustomRule("isEven", rule))
+	assert.Error(t, first.RegisterCustomRule("isEven", rule))
+}
+
+func TestValidatorInstanceTagName(t *testing.T) {
+	t.Parallel()
+
+	type Data struct {
+		Name string `check:"required"`
+	}
+
+	v := govalid.New(govalid.WithTagName("check"))
+	errs := v.ValidateStruct(Data{})
+	assert.Len(t, errs, 1)
+
+	errs = govalid.ValidateStruct(Data{})
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Contract struct {
+	Signers []string       `validate:"minItems=2,maxItems=3,dive,min=3"`
+	Hash    [4]byte        `validate:"required"`
+	Parts   []int          `validate:"len=2"`
+	Meta    map[string]int `validate:"required,maxItems=1"`
+	Code    string         `validate:"len=3"`
+}
+
+func TestValidateItems(t *testing.T) {
+	contract := Contract{
+		Signers: []string{"Andi", "Bo"},
+		Hash:    [4]byte{1},
+		Parts:   []int{1, 2},
+		Meta:    map[string]int{"a": 1},
+		Code:    "ABC",
+	}
+
+	errs := govalid.ValidateStruct(contract)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Signers[1]"}, errorFields(errs))
+	assert.Equal(t, " must be greater than or equal to 3; ", errs[0].Err.Error())
+
+	errs = govalid.ValidateStruct(Contract{
+		Signers: []string{"Andi", "Budi", "Cici", "Dodi"},
+		Parts:   []int{1},
+		Meta:    map[string]int{},
+		Code:    "ABCD",
+	})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Signers", "Hash", "Parts", "Meta", "Code"}, errorFields(errs))
+	assert.Equal(t, " must contain at most 3 items, got 4", errs[0].Err.Error())
+	assert.Equal(t, " field is required;", errs[1].Err.Error())
+	assert.Equal(t, " must contain exactly 2 items, got 1", errs[2].Err.Error())
+	assert.Equal(t, " field is required;", errs[3].Err.Error())
+	assert.Equal(t, " must be exactly 3 characters long", errs[4].Err.Error())
+}
+
+func TestValidateVarItems(t *testing.T) {
+	assert.Empty(t, govalid.ValidateVar([]int{1, 2}, "minItems=2"))
+	assert.Equal(t, " must contain at least 3 items, got 2", govalid.ValidateVar([]int{1, 2}, "minItems=3")[0].Err.Error())
+	assert.Equal(t, " must contain at most 1 items, got 2", govalid.ValidateVar(map[int]int{1: 1, 2: 2}, "maxItems=1")[0].Err.Error())
+	assert.Equal(t, " minItems only supports slices, arrays and maps", govalid.ValidateVar(3, "minItems=1")[0].Err.Error())
+	assert.NotEmpty(t, govalid.ValidateVar(map[string]int{}, "required"))
+	assert.NotEmpty(t, govalid.ValidateVar([2]int{}, "required"))
+}
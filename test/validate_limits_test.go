@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type TreeNode struct {
+	Name     string `validate:"required"`
+	Parent   *TreeNode
+	Children []*TreeNode `validate:"dive"`
+}
+
+type ListNode struct {
+	Value int `validate:"min=1"`
+	Prev  *ListNode
+	Next  *ListNode
+}
+
+type Payload struct {
+	Values []int          `validate:"dive,min=0"`
+	Labels map[string]int `validate:"keys=required"`
+	Emails []string       `validate:"email"`
+}
+
+func TestValidateCycles(t *testing.T) {
+	root := &TreeNode{Name: "root"}
+	child := &TreeNode{Parent: root}
+	root.Children = []*TreeNode{child, {Name: "leaf", Parent: root}}
+	child.Children = []*TreeNode{root}
+
+	errs := govalid.ValidateStruct(root)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Children[0].Name"}, errorFields(errs))
+
+	// doubly linked list, the walk stops where it meets a node it is inside of
+	first := &ListNode{Value: 1}
+	second := &ListNode{Value: 0, Prev: first}
+	first.Next = second
+	second.Next = first
+
+	errs = govalid.ValidateStruct(*first)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Next.Value"}, errorFields(errs))
+}
+
+func TestValidateMaxDepth(t *testing.T) {
+	list := &ListNode{Value: 1}
+	for i := 0; i < 5; i++ {
+		list = &ListNode{Value: 1, Next: list}
+	}
+
+	v := govalid.New(govalid.WithMaxDepth(3))
+	errs := v.ValidateStruct(list)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Next.Next.Next.Value"}, errorFields(errs))
+
+	var limitErr *govalid.LimitExceededError
+	assert.ErrorAs(t, errs[0].Err, &limitErr)
+	assert.Equal(t, "MaxDepth", limitErr.Limit)
+
+	err := v.Validate(list)
+	assert.True(t, errors.Is(err, govalid.ErrLimitExceeded))
+
+	assert.Empty(t, govalid.New(govalid.WithMaxDepth(7)).ValidateStruct(list))
+}
+
+func TestValidateMaxNodesAndCollectionLen(t *testing.T) {
+	payload := Payload{Values: make([]int, 100), Labels: map[string]int{"a": 1, "b": 2}}
+
+	errs := govalid.New(govalid.WithMaxNodes(50)).ValidateStruct(payload)
+	assert.Equal(t, []string{"Values[49]"}, errorFields(errs))
+	assert.Equal(t, "validation limit exceeded: MaxNodes is 50", errs[0].Err.Error())
+
+	errs = govalid.New(govalid.WithMaxCollectionLen(10)).ValidateStruct(payload)
+	assert.Equal(t, []string{"Values"}, errorFields(errs))
+	assert.True(t, errors.Is(errs[0].Err, govalid.ErrLimitExceeded))
+
+	errs = govalid.New(govalid.WithMaxCollectionLen(1)).ValidatePartial(payload, "Labels")
+	assert.Equal(t, []string{"Labels"}, errorFields(errs))
+
+	assert.Empty(t, govalid.New(govalid.WithMaxCollectionLen(100), govalid.WithMaxNodes(200)).ValidateStruct(payload))
+}
+
+func TestValidateCollectionLenSliceRules(t *testing.T) {
+	// min, max and email check every element of a slice without a dive
+	payload := Payload{Emails: make([]string, 1000)}
+
+	errs := govalid.New(govalid.WithMaxCollectionLen(100)).ValidatePartial(payload, "Emails")
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Emails"}, errorFields(errs))
+	assert.True(t, errors.Is(errs[0].Err, govalid.ErrLimitExceeded))
+
+	payload.Emails = []string{"a@example.com", "b"}
+	errs = govalid.New(govalid.WithMaxCollectionLen(100)).ValidatePartial(payload, "Emails")
+	assert.Equal(t, "(b) invalid email format; ", errs[0].Err.Error())
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
 )
 
 type DataMap struct {
@@ -25,3 +26,62 @@ func TestValidationMap(t *testing.T) {
 		fmt.Println("Validation successful!")
 	}
 }
+
+type DBConfig struct {
+	Host string `validate:"required"`
+	Port int    `validate:"min=1,max=65535"`
+}
+
+type Settings struct {
+	Tags    map[string]string         `validate:"keys=min=3,values=required"`
+	Ports   map[int]string            `validate:"keys=min=1024"`
+	Config  map[string]DBConfig       `validate:"dive"`
+	Mirrors map[string]*DBConfig      `validate:"values=required"`
+	Groups  map[string]map[string]int `validate:"values=(keys=min=2;values=(min=1;max=9))"`
+	Lists   map[string][]string       `validate:"values=(minItems=1;dive;email)"`
+	Limits  map[string]int            `validate:"required,maxItems=2,dive,min=0"`
+}
+
+func TestValidationMapEntries(t *testing.T) {
+	settings := Settings{
+		Tags:    map[string]string{"env": "", "region": "ap", "os": "linux"},
+		Ports:   map[int]string{80: "http", 8080: "alt"},
+		Config:  map[string]DBConfig{"db": {Host: "localhost", Port: 0}, "cache": {Port: 6379}},
+		Mirrors: map[string]*DBConfig{"eu": nil, "us": {Host: "us", Port: 70000}},
+		Groups:  map[string]map[string]int{"a": {"x": 1, "yy": 10}},
+		Lists:   map[string][]string{"admins": {"root@example.com", "root"}, "users": {}},
+		Limits:  map[string]int{"cpu": -1},
+	}
+
+	errs := govalid.ValidateStruct(settings)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{
+		"Tags[env]", "Tags[os]",
+		"Ports[80]",
+		"Config[cache].Host", "Config[db].Port",
+		"Mirrors[eu]", "Mirrors[us].Port",
+		"Groups[a][x]", "Groups[a][yy]",
+		"Lists[admins][1]", "Lists[users]",
+		"Limits[cpu]",
+	}, errorFields(errs))
+
+	// value errors carry the value, key errors the key
+	assert.Equal(t, "", errs[0].Value)
+	assert.Equal(t, "os", errs[1].Value)
+	assert.Equal(t, 80, errs[2].Value)
+	assert.Equal(t, 0, errs[4].Value)
+	assert.Equal(t, " must be greater than or equal to 1024; ", errs[2].Err.Error())
+}
+
+func TestValidationMapPartial(t *testing.T) {
+	settings := Settings{
+		Config: map[string]DBConfig{"db": {Port: 0}, "cache": {Port: 0}},
+		Limits: map[string]int{"cpu": 1},
+	}
+
+	errs := govalid.ValidatePartial(settings, "Config[db]")
+	assert.Equal(t, []string{"Config[db].Host", "Config[db].Port"}, errorFields(errs))
+
+	errs = govalid.ValidateExcept(settings, "Config")
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type SignupAddress struct {
+	ZipCode string `json:"zip_code" validate:"required"`
+}
+
+type Signup struct {
+	Email    string          `json:"email_address,omitempty" validate:"required,email"`
+	Password string          `json:"-" validate:"required"`
+	Nickname string          `validate:"required"`
+	Address  SignupAddress   `json:"address"`
+	Others   []SignupAddress `json:"others" validate:"dive"`
+}
+
+func TestFieldNameFunc(t *testing.T) {
+	v := govalid.New(govalid.WithFieldNameFunc(govalid.FieldNameFromTag("json")))
+	signup := Signup{Email: "invalid", Others: []SignupAddress{{}}}
+
+	errs := v.ValidateStruct(signup)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"email_address", "Password", "Nickname", "address.zip_code", "others[0].zip_code"}, errorFields(errs))
+
+	structFields := []string{}
+	for _, err := range errs {
+		structFields = append(structFields, err.StructField)
+	}
+	assert.Equal(t, []string{"Email", "Password", "Nickname", "Address.ZipCode", "Others[0].ZipCode"}, structFields)
+
+	// filters keep using Go names
+	errs = v.ValidatePartial(signup, "Address.ZipCode")
+	assert.Equal(t, []string{"address.zip_code"}, errorFields(errs))
+
+	// the default validator keeps both names equal
+	errs = govalid.ValidatePartial(signup, "Address")
+	assert.Equal(t, "Address.ZipCode", errs[0].Field)
+	assert.Equal(t, "Address.ZipCode", errs[0].StructField)
+}
+
+func TestFieldNameFuncCustom(t *testing.T) {
+	v := govalid.New(govalid.WithFieldNameFunc(func(field reflect.StructField) string {
+		return strings.ToLower(field.Name)
+	}))
+
+	var got string
+	v.RegisterCustomRule("notAdmin", func(field string, value any) error {
+		got = field
+		return fmt.Errorf("%s must not be admin", field)
+	})
+
+	type Account struct {
+		UserName string `validate:"notAdmin"`
+	}
+
+	errs := v.ValidateStruct(Account{UserName: "admin"})
+	assert.Equal(t, "username", got)
+	assert.Equal(t, "username", errs[0].Field)
+	assert.Equal(t, "UserName", errs[0].StructField)
+}
+
+type Ticket struct {
+	Title  string `check:"required" msg:"title is mandatory"`
+	Urgent bool
+	Reason string `check_if:"Urgent=true,required"`
+}
+
+func TestCustomTagNames(t *testing.T) {
+	v := govalid.New(
+		govalid.WithTagName("check"),
+		govalid.WithConditionTagName("check_if"),
+		govalid.WithMessageTagName("msg"),
+	)
+
+	errs := v.ValidateStruct(Ticket{Urgent: true})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Title", "Reason"}, errorFields(errs))
+	assert.Equal(t, "title is mandatory", errs[0].Err.Error())
+
+	// the default validator does not read these tags
+	assert.Empty(t, govalid.ValidateStruct(Ticket{Urgent: true}))
+}
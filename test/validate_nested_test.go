@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Geo struct {
+	Lat float64 `validate:"min=-90,max=90"`
+	Lng float64 `validate:"min=-180,max=180"`
+}
+
+type NestedAddress struct {
+	City    string `validate:"required"`
+	Country string
+	Zip     string `validate_if:"Country=ID,required"`
+	Geo     Geo
+}
+
+type Audit struct {
+	CreatedBy string `validate:"required"`
+}
+
+type Customer struct {
+	Audit
+	Name     string        `validate:"required"`
+	Address  NestedAddress // no struct marker needed
+	Shipping *NestedAddress
+	Billing  *NestedAddress
+}
+
+func TestValidateNested(t *testing.T) {
+	customer := Customer{
+		Audit: Audit{CreatedBy: "admin"},
+		Name:  "John",
+		Address: NestedAddress{
+			City:    "Jakarta",
+			Country: "ID",
+			Geo:     Geo{Lat: 91, Lng: 10},
+		},
+		Shipping: &NestedAddress{Zip: "40111", Geo: Geo{Lng: -181}},
+	}
+
+	errs := govalid.ValidateStruct(customer)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Address.Zip", "Address.Geo.Lat", "Shipping.City", "Shipping.Geo.Lng"}, errorFields(errs))
+	assert.Equal(t, 91.0, errs[1].Value)
+	assert.Equal(t, "max=90", errs[1].Tag)
+}
+
+func TestValidateNestedEmbeddedAndPointers(t *testing.T) {
+	address := NestedAddress{City: "Bandung", Zip: "40111"}
+
+	// nil pointers are not descended into
+	errs := govalid.ValidateStruct(&Customer{Name: "John", Address: address})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Audit.CreatedBy"}, errorFields(errs))
+
+	errs = govalid.ValidateStruct(Customer{
+		Audit:    Audit{CreatedBy: "admin"},
+		Name:     "John",
+		Address:  address,
+		Shipping: &address,
+		Billing:  &NestedAddress{Zip: "40111", Geo: Geo{Lat: -100}},
+	})
+	assert.Equal(t, []string{"Billing.City", "Billing.Geo.Lat"}, errorFields(errs))
+}
+
+func TestValidateNestedPartial(t *testing.T) {
+	customer := Customer{
+		Name:     "John",
+		Shipping: &NestedAddress{Zip: "40111", Geo: Geo{Lat: 91}},
+	}
+
+	errs := govalid.ValidatePartial(customer, "Shipping.Geo")
+	assert.Equal(t, []string{"Shipping.Geo.Lat"}, errorFields(errs))
+
+	errs = govalid.ValidateExcept(customer, "Audit", "Address", "Shipping.Geo.Lat")
+	assert.Equal(t, []string{"Shipping.City"}, errorFields(errs))
+}
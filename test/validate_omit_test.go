@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type OptionalProfile struct {
+	Email    string            `validate:"omitempty,email"`
+	Name     string            `validate:"omitempty,min=3"`
+	Website  *string           `validate:"omitempty,min=5"`
+	Bio      *string           `validate:"omitnil,min=5"`
+	Tags     []string          `validate:"omitempty,minItems=2,dive,min=2"`
+	Aliases  []string          `validate:"omitnil,minItems=1"`
+	Backups  []string          `validate:"dive,omitempty,email"`
+	Links    map[string]string `validate:"values=(omitempty;min=4)"`
+	Owner    *Address          `validate:"omitempty"`
+	Manager  Address           `validate:"omitempty"`
+	Nickname string            `validate:"required,omitempty,min=3"`
+}
+
+func TestValidationOmitEmpty(t *testing.T) {
+	// empty optional fields are skipped
+	assert.Empty(t, govalid.ValidateStruct(OptionalProfile{Nickname: "gopher"}))
+
+	empty := ""
+	optional := OptionalProfile{
+		Website:  &empty,
+		Aliases:  []string{},
+		Backups:  []string{"", "backup@example.com"},
+		Links:    map[string]string{"blog": ""},
+		Nickname: "gopher",
+	}
+	errs := govalid.ValidateStruct(optional)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Aliases"}, errorFields(errs))
+
+	// set values are validated, omitnil still validates a pointer to ""
+	invalid := OptionalProfile{
+		Email:    "gopher",
+		Name:     "Go",
+		Bio:      &empty,
+		Tags:     []string{"a"},
+		Backups:  []string{"backup"},
+		Links:    map[string]string{"blog": "b"},
+		Manager:  Address{City: "x"},
+		Nickname: "",
+	}
+	errs = govalid.ValidateStruct(invalid)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{
+		"Email", "Name", "Bio", "Tags", "Tags[0]", "Backups[0]",
+		"Links[blog]", "Manager.ZipCode", "Manager.ZipCode", "Nickname",
+	}, errorFields(errs))
+
+	// rules before omitempty still apply
+	assert.Equal(t, "required", errs[len(errs)-1].Tag)
+}
+
+func TestValidationOmitVar(t *testing.T) {
+	assert.Empty(t, govalid.ValidateVar("", "omitempty,email"))
+	assert.Len(t, govalid.ValidateVar("x", "omitempty,email"), 1)
+
+	var missing *string
+	assert.Empty(t, govalid.ValidateVar(missing, "omitnil,required"))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type UserPartial struct {
+	Name     string            `validate:"required,min=3"`
+	Password string            `validate:"required,min=8"`
+	Address  Address           `validate:"struct"`
+	Tags     map[string]string `validate:"maps,keys=min=3"`
+}
+
+func errorFields(errs []govalid.ValidationError) []string {
+	var names []string
+	for _, err := range errs {
+		names = append(names, err.Field)
+	}
+	return names
+}
+
+func TestValidatePartial(t *testing.T) {
+	user := UserPartial{
+		Name:     "",
+		Password: "",
+		Address:  Address{City: "", ZipCode: "12345"},
+		Tags:     map[string]string{"e": "prod"},
+	}
+
+	errs := govalid.ValidatePartial(user, "Name")
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Name", "Name"}, errorFields(errs))
+
+	errs = govalid.ValidatePartial(user, "Tags")
+	assert.Equal(t, []string{"Tags[e]"}, errorFields(errs))
+
+	// Address.ZipCode is valid, so only Address.City is reported
+	errs = govalid.ValidatePartial(user, "Address.ZipCode")
+	assert.Empty(t, errs)
+
+	errs = govalid.ValidatePartial(user, "Address.City")
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Address.City"}, errorFields(errs))
+}
+
+func TestValidateExcept(t *testing.T) {
+	user := UserPartial{
+		Name:     "John",
+		Password: "",
+		Address:  Address{City: "", ZipCode: ""},
+	}
+
+	errs := govalid.ValidateExcept(user, "Password", "Address")
+	assert.Empty(t, errs)
+
+	errs = govalid.ValidateExcept(user, "Password", "Address.City")
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Address.ZipCode", "Address.ZipCode"}, errorFields(errs))
+}
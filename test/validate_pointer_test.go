@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Square struct {
+	Side float64 `validate:"min=1"`
+}
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Profile struct {
+	Nickname *string `validate:"required,min=3"`
+	Age      *int    `validate:"min=18"`
+	Bio      **string
+	Shape    Shape  `validate:"required"`
+	Extra    any    `validate:"min=3"`
+	Shapes   []any  `validate:"dive,required"`
+	secret   string `validate:"required"`
+	internal Square
+}
+
+func TestValidatePointers(t *testing.T) {
+	short, age := "Jo", 17
+
+	errs := govalid.ValidateStruct(Profile{Nickname: &short, Age: &age, Shape: Square{Side: 2}})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Nickname", "Age"}, errorFields(errs))
+	assert.Equal(t, "Jo", errs[0].Value)
+	assert.Equal(t, 17, errs[1].Value)
+
+	// nil pointers and interfaces only fail required
+	errs = govalid.ValidateStruct(Profile{})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Nickname", "Shape"}, errorFields(errs))
+	assert.Equal(t, " field is required;", errs[0].Err.Error())
+}
+
+func TestValidateInterfaces(t *testing.T) {
+	name := "Johnny"
+
+	errs := govalid.ValidateStruct(&Profile{
+		Nickname: &name,
+		Shape:    &Square{Side: 0.5},
+		Extra:    "ab",
+		Shapes:   []any{Square{}, nil, 3},
+	})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Shape.Side", "Extra", "Shapes[0].Side", "Shapes[1]"}, errorFields(errs))
+
+	errs = govalid.ValidateStruct(&Profile{Nickname: &name, Shape: Square{Side: 1}, Extra: []int{1}})
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Err.Error(), "(1) must be greater than or equal to 3")
+}
+
+func TestValidateUnexported(t *testing.T) {
+	name := "Johnny"
+	profile := Profile{Nickname: &name, Shape: Square{Side: 1}, internal: Square{Side: 0}}
+
+	assert.Empty(t, govalid.ValidateStruct(profile))
+
+	v := govalid.New(govalid.WithUnexportedFields())
+	errs := v.ValidateStruct(profile)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"secret", "internal.Side"}, errorFields(errs))
+
+	// pointers are addressable already
+	errs = v.ValidateStruct(&profile)
+	assert.Equal(t, []string{"secret", "internal.Side"}, errorFields(errs))
+}
+
+func TestValidateVarPointers(t *testing.T) {
+	var missing *string
+	empty := ""
+
+	assert.NotEmpty(t, govalid.ValidateVar(missing, "required"))
+	assert.NotEmpty(t, govalid.ValidateVar(&empty, "required"))
+	assert.Empty(t, govalid.ValidateVar(missing, "min=3"))
+	assert.NotEmpty(t, govalid.ValidateVar(nil, "required"))
+}
+
+type base struct {
+	ID   string `validate:"required"`
+	note string `validate:"required"`
+}
+
+type meta struct {
+	Slug string `validate:"min=3"`
+}
+
+type Article struct {
+	base
+	*meta
+	Title string `validate:"required"`
+}
+
+func TestValidateEmbeddedUnexported(t *testing.T) {
+	// promoted exported fields of embedded unexported types are validated,
+	// their unexported fields are not
+	errs := govalid.ValidateStruct(Article{meta: &meta{Slug: "go"}})
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"base.ID", "meta.Slug", "Title"}, errorFields(errs))
+
+	assert.Empty(t, govalid.ValidateStruct(&Article{base: base{ID: "1"}, Title: "Go"}))
+
+	v := govalid.New(govalid.WithUnexportedFields())
+	errs = v.ValidateStruct(Article{base: base{ID: "1"}, Title: "Go"})
+	assert.Equal(t, []string{"base.note"}, errorFields(errs))
+}
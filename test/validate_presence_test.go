@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Checkout struct {
+	Method     string
+	Plan       string
+	Amount     int
+	BankName   string
+	Billing    *Billing
+	CardNumber string  `validate:"required_unless=Method bank"`
+	IBAN       *string `validate:"required_with=BankName"`
+	SWIFT      string  `validate:"required_with_all=BankName IBAN"`
+	Email      string  `validate:"required_without=Phone"`
+	Phone      string  `validate:"required_without_all=Email Billing.Country"`
+	Invoice    string  `validate:"required_if=Plan team Amount 0"`
+	PromoCode  string  `validate:"excluded_if=Plan free"`
+	Referral   string  `validate:"excluded_unless=Plan pro,omitempty,min=4"`
+}
+
+func TestValidationPresence(t *testing.T) {
+	iban := "DE89370400440532013000"
+	valid := []Checkout{
+		{Method: "bank", Plan: "free", BankName: "Bank", IBAN: &iban, SWIFT: "DEUTDEFF", Email: "me@example.com"},
+		{Method: "card", CardNumber: "4111", Plan: "pro", Phone: "+628123456789", Referral: "gopher"},
+		{Method: "bank", Plan: "team", Amount: 10, Email: "me@example.com", Billing: &Billing{Country: "ID"}},
+	}
+	for _, checkout := range valid {
+		errs := govalid.ValidateStruct(checkout)
+		fmt.Println("Validation errors:", errs)
+		assert.Empty(t, errs)
+	}
+
+	invalid := Checkout{Method: "card", Plan: "free", BankName: "Bank", PromoCode: "FREE", Referral: "go"}
+	errs := govalid.ValidateStruct(invalid)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"CardNumber", "IBAN", "Email", "Phone", "PromoCode", "Referral", "Referral"}, errorFields(errs))
+	assert.Equal(t, "required_unless=Method bank", errs[0].Tag)
+	assert.Equal(t, " field is required;", errs[1].Err.Error())
+	assert.Equal(t, " must not be set;", errs[4].Err.Error())
+
+	// values holding spaces are quoted
+	type Transfer struct {
+		Method    string
+		Reference string `validate:"required_if=Method 'wire transfer'"`
+		Note      string `validate:"excluded_unless=Method wire\\ transfer"`
+	}
+	errs = govalid.ValidateStruct(Transfer{Method: "wire transfer"})
+	assert.Equal(t, []string{"Reference"}, errorFields(errs))
+	errs = govalid.ValidateStruct(Transfer{Method: "wire", Note: "note"})
+	assert.Equal(t, []string{"Note"}, errorFields(errs))
+
+	// the values of if and unless compare as the type of the field
+	errs = govalid.ValidateStruct(Checkout{Method: "bank", Plan: "team", Email: "me@example.com"})
+	assert.Equal(t, []string{"Invoice"}, errorFields(errs))
+}
+
+func TestValidationPresenceErrors(t *testing.T) {
+	type Odd struct {
+		Method string
+		Card   string `validate:"required_if=Method"`
+	}
+	type Unknown struct {
+		IBAN string `validate:"required,required_with=Bank"`
+	}
+	type BadValue struct {
+		Amount int
+		Note   string `validate:"excluded_unless=Amount zero"`
+	}
+
+	tests := []struct {
+		data   any
+		column int
+		msg    string
+	}{
+		{Odd{}, 1, "required_if expects pairs of a field and a value"},
+		{Unknown{}, 10, `field "Bank" not found in main.Unknown`},
+		{BadValue{}, 1, `invalid value "zero" for int`},
+	}
+	for _, tt := range tests {
+		errs := govalid.ValidateStruct(tt.data)
+		fmt.Println("Validation failed:", errs)
+		assert.Len(t, errs, 1)
+
+		var syntaxErr *govalid.TagSyntaxError
+		assert.True(t, errors.As(errs[0], &syntaxErr))
+		assert.Equal(t, tt.column, syntaxErr.Column)
+		assert.Equal(t, tt.msg, syntaxErr.Msg)
+	}
+
+	// there are no other fields outside of a struct
+	assert.Len(t, govalid.ValidateVar("", "required_with=Other"), 1)
+}
@@ -83,3 +83,25 @@ func TestValidateCustomRegex(t *testing.T) {
 		fmt.Println("Validation Passed")
 	}
 }
+
+func TestValidateBuiltinRegex(t *testing.T) {
+	tests := []struct {
+		rule  string
+		valid []string
+		bad   []string
+	}{
+		{"slug", []string{"my-post", "go2"}, []string{"My Post", "-post", "post-"}},
+		{"url", []string{"https://example.com/path?q=1", "http://www.go.dev"}, []string{"example", "ftp://example.com", "not a url: xhttps://example.com"}},
+		{"ipv4", []string{"192.168.0.1", "8.8.8.8"}, []string{"256.1.1.1", "1.2.3"}},
+		{"ipv6", []string{"2001:db8::1", "::1", "fe80::1%eth0"}, []string{"2001:db8::g", "192.168.0.1"}},
+	}
+
+	for _, tt := range tests {
+		for _, value := range tt.valid {
+			assert.Empty(t, govalid.ValidateVar(value, "regex="+tt.rule), "%s %s", tt.rule, value)
+		}
+		for _, value := range tt.bad {
+			assert.Len(t, govalid.ValidateVar(value, "regex="+tt.rule), 1, "%s %s", tt.rule, value)
+		}
+	}
+}
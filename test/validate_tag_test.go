@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type Product struct {
+	Code  string            `validate:"required,regex='^[A-Z]{2,4}-[0-9]+$'"`
+	Label string            `validate:"regex='^it\\'s'"`
+	Attrs map[string]string `validate:"keys=(regex='^[a-z]{1,3}$'),values=(required;min=2)"`
+}
+
+func TestTagQuoting(t *testing.T) {
+	valid := Product{Code: "AB-12", Label: "it's fine", Attrs: map[string]string{"id": "42"}}
+	assert.Empty(t, govalid.ValidateStruct(valid))
+
+	invalid := Product{Code: "ABCDE-12", Label: "fine", Attrs: map[string]string{"long": "4"}}
+	errs := govalid.ValidateStruct(invalid)
+	fmt.Println("Validation failed:", errs)
+	assert.Equal(t, []string{"Code", "Label", "Attrs[long]", "Attrs[long]"}, errorFields(errs))
+	assert.Equal(t, "regex='^[A-Z]{2,4}-[0-9]+$'", errs[0].Tag)
+	assert.Equal(t, " ABCDE-12 does not match the required pattern;", errs[0].Err.Error())
+}
+
+func TestTagEscapes(t *testing.T) {
+	v := govalid.New()
+	err := v.RegisterCustomRule("oneOf", func(field string, value any) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	type Escaped struct {
+		Size string `validate:"required,oneOf=s\\,m\\,l,min=1"`
+	}
+	errs := v.ValidateStruct(Escaped{})
+	assert.Equal(t, []string{"Size", "Size"}, errorFields(errs))
+	assert.Equal(t, []string{"required", "min=1"}, []string{errs[0].Tag, errs[1].Tag})
+
+	type Sized struct {
+		Sizes string
+		Stock int `validate:"required_if=Sizes s\\,m"`
+	}
+	assert.Equal(t, []string{"Stock"}, errorFields(v.ValidateStruct(Sized{Sizes: "s,m"})))
+	assert.Empty(t, v.ValidateStruct(Sized{Sizes: "s"}))
+}
+
+func TestTagSyntaxErrors(t *testing.T) {
+	type Unterminated struct {
+		Code string `validate:"required,regex='^[a-z]+$"`
+	}
+	type Unbalanced struct {
+		Tags map[string]string `validate:"keys=(min=2"`
+	}
+	type DiveString struct {
+		Name string `validate:"required,dive,min=2"`
+	}
+
+	tests := []struct {
+		data   any
+		column int
+		msg    string
+	}{
+		{Unterminated{}, 16, `invalid tag "required,regex='^[a-z]+$" on main.Unterminated.Code at column 16: unterminated quote`},
+		{Unbalanced{}, 6, `invalid tag "keys=(min=2" on main.Unbalanced.Tags at column 6: missing )`},
+		{DiveString{}, 10, `invalid tag "required,dive,min=2" on main.DiveString.Name at column 10: dive requires a slice, an array or a map, got string`},
+	}
+
+	for _, tt := range tests {
+		errs := govalid.ValidateStruct(tt.data)
+		fmt.Println("Validation failed:", errs)
+		assert.Len(t, errs, 1)
+
+		var syntaxErr *govalid.TagSyntaxError
+		assert.True(t, errors.As(errs[0], &syntaxErr))
+		assert.Equal(t, tt.column, syntaxErr.Column)
+		assert.Equal(t, tt.msg, syntaxErr.Error())
+	}
+}
+
+type Typos struct {
+	Name  string `validate:"requried,min=2"`
+	Age   int    `validate:"min=abc"`
+	Email string `validate:"required,,email"`
+}
+
+func TestStrictTags(t *testing.T) {
+	// unknown rules, empty rules and bad parameters are ignored by default
+	errs := govalid.ValidateStruct(Typos{Name: "Al", Email: "al@example.com"})
+	assert.Empty(t, errs)
+
+	v := govalid.New(govalid.WithStrictTags())
+	errs = v.ValidateStruct(Typos{})
+	fmt.Println("Validation failed:", errs)
+	assert.Len(t, errs, 1)
+
+	var syntaxErr *govalid.TagSyntaxError
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, "Name", syntaxErr.Field)
+	assert.Equal(t, 1, syntaxErr.Column)
+	assert.Equal(t, `unknown rule "requried"`, syntaxErr.Msg)
+
+	type Age struct {
+		Age int `validate:"required,min=abc"`
+	}
+	type Empty struct {
+		Email string `validate:"required,,email"`
+	}
+	type BadPattern struct {
+		Code string `validate:"regex='[a-z'"`
+	}
+
+	tests := []struct {
+		data   any
+		column int
+		msg    string
+	}{
+		{Age{}, 10, `invalid parameter "abc" for min, expected a number`},
+		{Empty{}, 10, `empty rule`},
+		{BadPattern{}, 1, "invalid regex pattern \"[a-z\": error parsing regexp: missing closing ]: `[a-z`"},
+	}
+	for _, tt := range tests {
+		errs := v.ValidateStruct(tt.data)
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &syntaxErr))
+		assert.Equal(t, tt.column, syntaxErr.Column)
+		assert.Equal(t, tt.msg, syntaxErr.Msg)
+	}
+
+	// rules registered after a failed compile are picked up
+	type Later struct {
+		Code string `validate:"upper"`
+	}
+	errs = v.ValidateStruct(Later{})
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+
+	err := v.RegisterCustomRule("upper", func(field string, value any) error {
+		return errors.New(" must be upper case")
+	})
+	assert.NoError(t, err)
+	errs = v.ValidateStruct(Later{})
+	assert.Equal(t, []string{"Code"}, errorFields(errs))
+	assert.Equal(t, "upper", errs[0].Tag)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	govalid "github.com/harrysan/govalid/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVar(t *testing.T) {
+	tests := []struct {
+		value  any
+		tag    string
+		hasErr bool
+	}{
+		{"john@doe.com", "required,email", false},
+		{"invalid_email", "required,email", true},
+		{"", "required", true},
+		{nil, "required", true},
+		{"Jo", "min=3", true},
+		{42, "min=18,max=99", false},
+		{[]string{"John", "Do"}, "slice,min=3", true},
+		{"Johns", "regex=username", false},
+	}
+
+	for _, tt := range tests {
+		errs := govalid.ValidateVar(tt.value, tt.tag)
+		fmt.Println(errs)
+		assert.Equal(t, tt.hasErr, len(errs) > 0, "ValidateVar(%#v, %q)", tt.value, tt.tag)
+	}
+}
+
+func TestValidateVarError(t *testing.T) {
+	errs := govalid.ValidateVar("Jo", "required,min=3")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "min=3", errs[0].Tag)
+	assert.Equal(t, "Jo", errs[0].Value)
+}
+
+func TestValidateVarWithValue(t *testing.T) {
+	tests := []struct {
+		value  any
+		other  any
+		tag    string
+		hasErr bool
+	}{
+		{"secret", "secret", "eqfield", false},
+		{"secret", "Secret", "eqfield", true},
+		{"secret", "secret", "nefield", true},
+		{10, 5, "gtfield", false},
+		{5, 5, "gtfield", true},
+		{5, 5, "gtefield", false},
+		{2.5, 3.0, "ltfield", false},
+		{int64(3), int32(3), "ltefield", false},
+		{5, "5", "eqfield", true},
+	}
+
+	for _, tt := range tests {
+		errs := govalid.ValidateVarWithValue(tt.value, tt.other, tt.tag)
+		fmt.Println(errs)
+		assert.Equal(t, tt.hasErr, len(errs) > 0, "ValidateVarWithValue(%#v, %#v, %q)", tt.value, tt.other, tt.tag)
+	}
+}
+
+func TestValidateVarCrossField(t *testing.T) {
+	for _, v := range []*govalid.Validator{govalid.Default(), govalid.New(govalid.WithStrictTags())} {
+		errs := v.ValidateVar(1, "gtcsfield=Start")
+		fmt.Println(errs)
+		if assert.Len(t, errs, 1) {
+			assert.EqualError(t, errs[0].Err, " gtcsfield only applies to the fields of a struct;")
+		}
+	}
+
+	errs := govalid.ValidateVarWithValue(1, 0, "eqcsfield=Start")
+	assert.Len(t, errs, 1)
+}
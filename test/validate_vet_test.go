@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVetModule runs the tests of the govalid-vet module, which requires a
+// released validator, against this checkout through a temporary workspace
+func TestVetModule(t *testing.T) {
+	root, err := filepath.Abs("..")
+	if !assert.NoError(t, err) {
+		return
+	}
+	vet := filepath.Join(root, "cmd", "govalid-vet")
+
+	work := filepath.Join(t.TempDir(), "go.work")
+	content := fmt.Sprintf("go 1.25.0\n\nuse %q\n\nreplace github.com/harrysan/govalid => %q\n", vet, root)
+	if !assert.NoError(t, os.WriteFile(work, []byte(content), 0o644)) {
+		return
+	}
+
+	// -mod=mod is rejected in workspace mode
+	cmd := exec.Command("go", "test", "./...")
+	cmd.Dir = vet
+	cmd.Env = append(os.Environ(), "GOWORK="+work, "GOFLAGS=")
+	out, err := cmd.CombinedOutput()
+	fmt.Println(string(out))
+	assert.NoError(t, err, string(out))
+}
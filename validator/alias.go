@@ -0,0 +1,87 @@
+package govalid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/harrysan/govalid/internal/tag"
+)
+
+// RegisterAlias registers an alias on the default Validator
+func RegisterAlias(name, tags string) error {
+	return defaultValidator.RegisterAlias(name, tags)
+}
+
+// RegisterAlias registers a name for a list of rules on this Validator only,
+// e.g. RegisterAlias("password", "required,min=8,max=64"), so tags can use
+// validate:"password". Aliases may use other aliases, registering one that
+// closes a cycle fails.
+func (v *Validator) RegisterAlias(name, tags string) error {
+	if name == "" || tags == "" {
+		return errors.New("alias needs a name and rules")
+	}
+	if _, err := tag.Parse(tags, ","); err != nil {
+		return fmt.Errorf("invalid alias %s: %w", name, err)
+	}
+
+	v.customMu.Lock()
+	defer v.customMu.Unlock()
+
+	if _, exists := v.customRules[name]; exists || v.aliases[name] != "" || tag.Builtin(name) {
+		return errors.New("rule already exists: " + name)
+	}
+	v.aliases[name] = tags
+	if cycle := v.aliasCycle(name, nil); cycle != nil {
+		delete(v.aliases, name)
+		return errors.New("alias cycle: " + strings.Join(cycle, " -> "))
+	}
+
+	// plans compiled before ignored the alias as an unknown rule
+	v.resetPlans()
+	return nil
+}
+
+// alias looks up the rules of an alias, safe for concurrent use with registration
+func (v *Validator) alias(name string) (string, bool) {
+	v.customMu.RLock()
+	defer v.customMu.RUnlock()
+
+	tags, exists := v.aliases[name]
+	return tags, exists
+}
+
+// aliasCycle returns the aliases of a cycle going through name, seen being
+// the aliases on the way to it, or nil. The caller holds customMu.
+func (v *Validator) aliasCycle(name string, seen []string) []string {
+	if slices.Contains(seen, name) {
+		return append(seen, name)
+	}
+	tags, exists := v.aliases[name]
+	if !exists {
+		return nil
+	}
+
+	seen = append(seen, name)
+	var walk func(list []tag.Rule) []string
+	walk = func(list []tag.Rule) []string {
+		for _, r := range list {
+			// aliases may be used inside the rule lists of keys= and values=
+			if r.Name == "keys" || r.Name == "values" {
+				sub, _ := tag.ParseList(r)
+				if cycle := walk(sub); cycle != nil {
+					return cycle
+				}
+				continue
+			}
+			if cycle := v.aliasCycle(r.Name, seen); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+
+	parsed, _ := tag.Parse(tags, ",")
+	return walk(parsed)
+}
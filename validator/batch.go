@@ -0,0 +1,119 @@
+package govalid
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchOptions configures ValidateAll
+type BatchOptions struct {
+	// Workers is the number of goroutines, defaults to GOMAXPROCS
+	Workers int
+	// FailFast stops at the first failing element by position
+	FailFast bool
+}
+
+// ItemErrors are the validation errors of one element of a batch
+type ItemErrors struct {
+	Index  int
+	Errors []ValidationError
+}
+
+// ValidateAll validates every element of a slice using the default Validator
+func ValidateAll(items any, opts BatchOptions) ([]ItemErrors, error) {
+	return defaultValidator.ValidateAll(items, opts)
+}
+
+// ValidateAllCtx is ValidateAll with a context using the default Validator
+func ValidateAllCtx(ctx context.Context, items any, opts BatchOptions) ([]ItemErrors, error) {
+	return defaultValidator.ValidateAllCtx(ctx, items, opts)
+}
+
+// ValidateAll validates every element of a slice or array of structs (or
+// pointers to structs) on a bounded worker pool
+func (v *Validator) ValidateAll(items any, opts BatchOptions) ([]ItemErrors, error) {
+	return v.ValidateAllCtx(context.Background(), items, opts)
+}
+
+// ValidateAllCtx validates every element of a slice or array of structs on a
+// bounded worker pool. Only failing elements are returned, ordered by index.
+// With FailFast only the first failing element by position is returned and
+// elements after it are no longer scheduled. Once ctx is done no element is
+// scheduled anymore and ctx.Err() is returned without results.
+func (v *Validator) ValidateAllCtx(ctx context.Context, items any, opts BatchOptions) ([]ItemErrors, error) {
+	val := reflect.ValueOf(items)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		var typ reflect.Type
+		if val.IsValid() {
+			typ = val.Type()
+		}
+		return nil, &InvalidValidationError{Type: typ, Expected: "slice or array"}
+	}
+
+	n := val.Len()
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	results := make([][]ValidationError, n)
+	var next atomic.Int64
+	var firstFailed atomic.Int64
+	firstFailed.Store(int64(n))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+				idx := int(next.Add(1) - 1)
+				if idx >= n || (opts.FailFast && int64(idx) > firstFailed.Load()) {
+					return
+				}
+
+				errs, err := v.validate(ctx, val.Index(idx).Interface(), nil)
+				if err != nil {
+					errs = []ValidationError{usageError(err)}
+				}
+				if len(errs) == 0 {
+					continue
+				}
+				results[idx] = errs
+
+				for failed := firstFailed.Load(); int64(idx) < failed; failed = firstFailed.Load() {
+					if firstFailed.CompareAndSwap(failed, int64(idx)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// elements validated while ctx was cancelled hold its error
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var out []ItemErrors
+	for idx, errs := range results {
+		if errs == nil {
+			continue
+		}
+		out = append(out, ItemErrors{Index: idx, Errors: errs})
+		if opts.FailFast {
+			break
+		}
+	}
+	return out, nil
+}
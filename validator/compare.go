@@ -0,0 +1,232 @@
+package govalid
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/harrysan/govalid/rules"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// fieldRules maps the rules comparing a field with another one to the
+// comparison they make, the csfield variants take a path from the root
+var fieldRules = map[string]string{
+	"eqfield": "eqfield", "nefield": "nefield",
+	"gtfield": "gtfield", "gtefield": "gtefield",
+	"ltfield": "ltfield", "ltefield": "ltefield",
+	"eqcsfield": "eqfield", "necsfield": "nefield",
+	"gtcsfield": "gtfield", "gtecsfield": "gtefield",
+	"ltcsfield": "ltfield", "ltecsfield": "ltefield",
+}
+
+// fieldRef is the field a rule of the eqfield family compares with
+type fieldRef struct {
+	cmp   string   // comparison, e.g. gtfield for gtcsfield
+	cross bool     // path from the root instead of the parent struct
+	path  []string // dotted path, e.g. Account.Email
+	index [][]int  // field index of every segment, resolved for siblings
+}
+
+// resolveRefs resolves the fields the eqfield and required_if families of
+// rules of a field plan and its elements, keys and values refer to.
+// Siblings are looked up in parent and checked against the type of the
+// compared value now, paths from the root are only known while validating.
+func resolveRefs(fp *fieldPlan, parent reflect.Type) error {
+	if fp == nil {
+		return nil
+	}
+
+	for _, list := range [][]*rule{fp.rules, fp.raw} {
+		for _, r := range list {
+			if err := resolveRef(r, fp.typ, parent); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sub := range []*fieldPlan{fp.dive, fp.keys, fp.values} {
+		if err := resolveRefs(sub, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveRef resolves the field a rule compares with, typ being the type
+// of the compared value
+func resolveRef(r *rule, typ, parent reflect.Type) error {
+	for _, alt := range r.or {
+		if err := resolveRef(alt, typ, parent); err != nil {
+			return err
+		}
+	}
+
+	if presenceRules[r.name] && r.dep == nil {
+		return resolveDependency(r, parent)
+	}
+
+	cmp, ok := fieldRules[r.name]
+	if !ok || r.ref != nil {
+		return nil
+	}
+	if r.param == "" {
+		return ruleError(r, r.name+" requires the name of a field")
+	}
+
+	r.ref = &fieldRef{cmp: cmp, cross: strings.Contains(r.name, "csfield"), path: strings.Split(r.param, ".")}
+	if r.ref.cross {
+		return nil
+	}
+
+	other, index, err := fieldByPath(parent, r.ref.path)
+	if err != nil {
+		return ruleError(r, err.Error())
+	}
+	r.ref.index = index
+
+	if typ != nil && typ.Kind() != reflect.Interface && other.Kind() != reflect.Interface && !comparableTypes(cmp, typ, other) {
+		return ruleError(r, fmt.Sprintf("%s cannot compare %s with %s", r.name, typ, other))
+	}
+	return nil
+}
+
+// fieldByPath looks up a dotted path of exported fields in a struct type,
+// returning the type of the field, without pointers, and the index of
+// every segment
+func fieldByPath(typ reflect.Type, path []string) (reflect.Type, [][]int, error) {
+	index := make([][]int, 0, len(path))
+	for _, name := range path {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("%s is not a struct, cannot look up field %q", typ, name)
+		}
+
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return nil, nil, fmt.Errorf("field %q not found in %s", name, typ)
+		}
+		if !field.IsExported() {
+			return nil, nil, fmt.Errorf("field %q of %s is not exported", name, typ)
+		}
+		index = append(index, field.Index)
+		typ = field.Type
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ, index, nil
+}
+
+// comparableTypes reports whether a comparison of the eqfield family
+// applies to values of two types. Times and durations only compare with
+// their own type, eqfield and nefield also compare values of the same type.
+func comparableTypes(cmp string, a, b reflect.Type) bool {
+	if a == timeType || b == timeType || a == durationType || b == durationType {
+		return a == b
+	}
+
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()),
+		isUintKind(a.Kind()) && isUintKind(b.Kind()),
+		isFloatKind(a.Kind()) && isFloatKind(b.Kind()),
+		a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return true
+	}
+	return (cmp == "eqfield" || cmp == "nefield") && a == b
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// compareField applies a rule of the eqfield family, looking up the field it
+// compares with in the struct being validated or the root
+func (w *walker) compareField(fieldName string, value any, r *rule) error {
+	base := w.parent
+	if r.ref.cross {
+		base = w.root
+	}
+
+	other, err := fieldAt(base, r.ref)
+	if err != nil {
+		return &TagSyntaxError{Field: fieldName, Tag: r.tag, Msg: err.Error()}
+	}
+	if !other.IsValid() {
+		return fmt.Errorf(" cannot compare with %s, it is missing", r.param)
+	}
+
+	if r.ref.cross {
+		typ := reflect.TypeOf(value)
+		if !comparableTypes(r.ref.cmp, typ, other.Type()) {
+			return &TagSyntaxError{Field: fieldName, Tag: r.tag, Msg: fmt.Sprintf("%s cannot compare %s with %s", r.name, typ, other.Type())}
+		}
+	}
+
+	return rules.ValidateRuleCompareField(value, other.Interface(), r.ref.cmp, r.param)
+}
+
+// fieldAt returns the value of the field a rule compares with, an invalid
+// value when a pointer on the way is nil
+func fieldAt(val reflect.Value, ref *fieldRef) (reflect.Value, error) {
+	if !val.IsValid() {
+		return reflect.Value{}, fmt.Errorf("no struct to look up field %s in", strings.Join(ref.path, "."))
+	}
+
+	for i, name := range ref.path {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return reflect.Value{}, nil
+			}
+			val = val.Elem()
+		}
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%s is not a struct, cannot look up field %q", val.Type(), name)
+		}
+
+		index := []int(nil)
+		if ref.index != nil {
+			index = ref.index[i]
+		} else {
+			field, ok := val.Type().FieldByName(name)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("field %q not found in %s", name, val.Type())
+			}
+			if !field.IsExported() {
+				return reflect.Value{}, fmt.Errorf("field %q of %s is not exported", name, val.Type())
+			}
+			index = field.Index
+		}
+
+		field, err := val.FieldByIndexErr(index)
+		if err != nil {
+			// nil embedded pointer
+			return reflect.Value{}, nil
+		}
+		val = field
+	}
+
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}, nil
+		}
+		val = val.Elem()
+	}
+	return val, nil
+}
@@ -0,0 +1,206 @@
+package govalid
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrysan/govalid/internal/tag"
+)
+
+// condition is a parsed validate_if tag, its rules apply when all the
+// comparisons of one of its alternatives hold
+type condition struct {
+	or   [][]*comparison
+	plan *fieldPlan // rules applying when the condition holds
+}
+
+// comparison compares a field of the struct with values of its type
+type comparison struct {
+	ref    *fieldRef
+	op     string
+	values []any // int64, uint64, float64, bool, string or time.Time
+}
+
+// compileCondition parses a validate_if tag of a field of a struct type,
+// resolving the fields it compares and parsing its values as their types
+func (v *Validator) compileCondition(typ reflect.Type, field reflect.StructField, tagVIf string) (*condition, error) {
+	parsed, err := tag.ParseCondition(tagVIf)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &condition{}
+	for _, and := range parsed.Or {
+		list := make([]*comparison, 0, len(and))
+		for _, src := range and {
+			compiled, err := compileComparison(typ, src)
+			if err != nil {
+				return nil, &tag.SyntaxError{Column: src.Column, Msg: err.Error()}
+			}
+			list = append(list, compiled)
+		}
+		c.or = append(c.or, list)
+	}
+
+	rules, err := v.newRules(parsed.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if c.plan, err = v.compileRules(field.Name, field.Type, rules); err != nil {
+		return nil, err
+	}
+	// nested structs are walked by the plan of the validate tag
+	c.plan.nested = false
+	if err := resolveRefs(c.plan, typ); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// compileComparison resolves the field of a comparison in a struct type
+// and parses its values
+func compileComparison(parent reflect.Type, src tag.Comparison) (*comparison, error) {
+	path := strings.Split(src.Field, ".")
+	typ, index, err := fieldByPath(parent, path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &comparison{ref: &fieldRef{path: path, index: index}, op: src.Op}
+	if ordered := src.Op == ">" || src.Op == ">=" || src.Op == "<" || src.Op == "<="; ordered &&
+		!isIntKind(typ.Kind()) && !isUintKind(typ.Kind()) && !isFloatKind(typ.Kind()) && typ != timeType {
+		return nil, fmt.Errorf("%s requires a number, a time or a duration, %s is %s", src.Op, src.Field, typ)
+	}
+
+	for _, s := range src.Values {
+		value, err := literal(typ, s)
+		if err != nil {
+			return nil, err
+		}
+		c.values = append(c.values, value)
+	}
+	return c, nil
+}
+
+// literal parses a value of a condition as a value of the given type,
+// times are written in RFC 3339 and durations like 1h30m
+func literal(typ reflect.Type, s string) (any, error) {
+	var value any
+	var err error
+
+	switch {
+	case typ == timeType:
+		value, err = time.Parse(time.RFC3339, s)
+	case typ == durationType:
+		var d time.Duration
+		d, err = time.ParseDuration(s)
+		value = int64(d)
+	case isIntKind(typ.Kind()):
+		value, err = strconv.ParseInt(s, 10, typ.Bits())
+	case isUintKind(typ.Kind()):
+		value, err = strconv.ParseUint(s, 10, typ.Bits())
+	case isFloatKind(typ.Kind()):
+		value, err = strconv.ParseFloat(s, typ.Bits())
+	case typ.Kind() == reflect.Bool:
+		value, err = strconv.ParseBool(s)
+	case typ.Kind() == reflect.String:
+		value = s
+	default:
+		return nil, fmt.Errorf("cannot compare %s in a condition", typ)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for %s", s, typ)
+	}
+	return value, nil
+}
+
+// holds reports whether the condition holds for a struct value
+func (c *condition) holds(parent reflect.Value) bool {
+	for _, and := range c.or {
+		all := true
+		for _, comp := range and {
+			if !comp.holds(parent) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+// holds reports whether a comparison holds for a struct value, it does not
+// when a pointer on the path of its field is nil
+func (c *comparison) holds(parent reflect.Value) bool {
+	field, err := fieldAt(parent, c.ref)
+	if err != nil || !field.IsValid() {
+		return false
+	}
+
+	value := typedValue(field)
+	for _, want := range c.values {
+		n := order(value, want)
+		switch c.op {
+		case "=", "in":
+			if n == 0 {
+				return true
+			}
+		case "!=":
+			return n != 0
+		case ">":
+			return n > 0
+		case ">=":
+			return n >= 0
+		case "<":
+			return n < 0
+		case "<=":
+			return n <= 0
+		}
+	}
+	return false
+}
+
+// typedValue returns a field as the type literal parses its values to
+func typedValue(field reflect.Value) any {
+	switch {
+	case field.Type() == timeType:
+		return field.Interface().(time.Time)
+	case isIntKind(field.Kind()):
+		return field.Int()
+	case isUintKind(field.Kind()):
+		return field.Uint()
+	case isFloatKind(field.Kind()):
+		return field.Float()
+	case field.Kind() == reflect.Bool:
+		return field.Bool()
+	}
+	return field.String()
+}
+
+// order compares two values of the same type, bools being either equal or not
+func order(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case uint64:
+		return cmp.Compare(a, b.(uint64))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return cmp.Compare(a, b.(string))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+
+	if a == b {
+		return 0
+	}
+	return 1
+}
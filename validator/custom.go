@@ -1,23 +1,62 @@
 package govalid
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 type CustomRule func(field string, value any) error
 
-var customRules = map[string]CustomRule{}
+// CustomRuleCtx is a custom rule that honors the deadline and cancellation
+// of the context passed to ValidateStructCtx
+type CustomRuleCtx func(ctx context.Context, field string, value any) error
 
+// RegisterCustomRule registers a custom rule on the default Validator
 func RegisterCustomRule(name string, rule CustomRule) error {
-	if _, exists := customRules[name]; exists {
+	return defaultValidator.RegisterCustomRule(name, rule)
+}
+
+// RegisterCustomRuleCtx registers a context aware custom rule on the default Validator
+func RegisterCustomRuleCtx(name string, rule CustomRuleCtx) error {
+	return defaultValidator.RegisterCustomRuleCtx(name, rule)
+}
+
+// RegisterCustomRule registers a custom rule on this Validator only
+func (v *Validator) RegisterCustomRule(name string, rule CustomRule) error {
+	return v.RegisterCustomRuleCtx(name, func(_ context.Context, field string, value any) error {
+		return rule(field, value)
+	})
+}
+
+// RegisterCustomRuleCtx registers a context aware custom rule on this Validator only
+func (v *Validator) RegisterCustomRuleCtx(name string, rule CustomRuleCtx) error {
+	v.customMu.Lock()
+	defer v.customMu.Unlock()
+
+	if _, exists := v.customRules[name]; exists || v.aliases[name] != "" {
 		return errors.New("rule already exists: " + name)
 	}
-	customRules[name] = rule
+	v.customRules[name] = rule
+	// plans compiled in strict mode may have rejected the rule
+	if v.strict {
+		v.resetPlans()
+	}
 	return nil
 }
 
-func applyCustomRule(ruleName string, fieldName string, value any) error {
-	rule, exists := customRules[ruleName]
+// customRule looks up a custom rule, safe for concurrent use with registration
+func (v *Validator) customRule(name string) (CustomRuleCtx, bool) {
+	v.customMu.RLock()
+	defer v.customMu.RUnlock()
+
+	rule, exists := v.customRules[name]
+	return rule, exists
+}
+
+func (v *Validator) applyCustomRule(ctx context.Context, ruleName string, fieldName string, value any) error {
+	rule, exists := v.customRule(ruleName)
 	if !exists {
 		return errors.New("custom rule not found: " + ruleName)
 	}
-	return rule(fieldName, value)
+	return rule(ctx, fieldName, value)
 }
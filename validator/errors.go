@@ -0,0 +1,132 @@
+package govalid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/harrysan/govalid/internal/tag"
+)
+
+// ValidationErrors is the error returned by Validate when fields fail validation
+type ValidationErrors []ValidationError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for i, e := range ve {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As look into every field error
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for i := range ve {
+		errs[i] = ve[i]
+	}
+	return errs
+}
+
+// InvalidValidationError is returned when the input is not a struct or a
+// non-nil pointer to a struct
+type InvalidValidationError struct {
+	Type reflect.Type
+	// Expected describes the accepted input, defaults to "struct"
+	Expected string
+}
+
+func (e *InvalidValidationError) Error() string {
+	expected := e.Expected
+	if expected == "" {
+		expected = "struct"
+	}
+
+	if e.Type == nil {
+		return "Validate: input must be a " + expected + ", got nil"
+	}
+	if e.Type.Kind() == reflect.Ptr {
+		return "Validate: input must be a " + expected + ", got nil " + e.Type.String()
+	}
+	return "Validate: input must be a " + expected + ", got " + e.Type.String()
+}
+
+// AlternativesError is the error of an OR group of rules, e.g.
+// regex=email|regex=phone_number, when none of its alternatives passed
+type AlternativesError struct {
+	Tags []string // tags of the alternatives
+	Errs []error  // error of each alternative
+}
+
+func (e *AlternativesError) Error() string {
+	var b strings.Builder
+	b.WriteString(" must pass one of ")
+	for i, t := range e.Tags {
+		if i > 0 {
+			b.WriteString(" | ")
+		}
+		fmt.Fprintf(&b, "%s (%s)", t, strings.TrimSpace(e.Errs[i].Error()))
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+// Unwrap lets errors.Is and errors.As look into the error of every alternative
+func (e *AlternativesError) Unwrap() []error {
+	return e.Errs
+}
+
+// TagSyntaxError is returned when a tag of a struct field cannot be parsed
+type TagSyntaxError struct {
+	Struct string
+	Field  string
+	Tag    string
+	Column int // 1-based column in Tag, 0 when unknown
+	Msg    string
+}
+
+// newTagSyntaxError wraps an error compiling a tag, taking its column from
+// a *tag.SyntaxError
+func newTagSyntaxError(structName, field, tagValue string, err error) *TagSyntaxError {
+	e := &TagSyntaxError{Struct: structName, Field: field, Tag: tagValue, Msg: err.Error()}
+
+	var syntaxErr *tag.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		e.Column = syntaxErr.Column
+		e.Msg = strings.Replace(err.Error(), syntaxErr.Error(), syntaxErr.Msg, 1)
+	}
+	return e
+}
+
+func (e *TagSyntaxError) Error() string {
+	at := ""
+	switch {
+	case e.Struct != "":
+		at = " on " + e.Struct + "." + e.Field
+	case e.Field != "":
+		at = " on " + e.Field
+	}
+	if e.Column > 0 {
+		at += fmt.Sprintf(" at column %d", e.Column)
+	}
+	return fmt.Sprintf("invalid tag %q%s: %s", e.Tag, at, e.Msg)
+}
+
+// ErrLimitExceeded matches every LimitExceededError with errors.Is
+var ErrLimitExceeded = errors.New("validation limit exceeded")
+
+// LimitExceededError ends a run that went past one of the limits set with
+// WithMaxDepth, WithMaxNodes or WithMaxCollectionLen
+type LimitExceededError struct {
+	Limit string // MaxDepth, MaxNodes or MaxCollectionLen
+	Max   int
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("validation limit exceeded: %s is %d", e.Limit, e.Max)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
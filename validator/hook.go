@@ -0,0 +1,96 @@
+package govalid
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// StructValidator is implemented by structs with rules that do not fit in a
+// tag, like "end date after start date". ValidateWith is called on the root
+// struct and on every nested struct visited by the walk, after its fields.
+type StructValidator interface {
+	ValidateWith(r *Reporter)
+}
+
+var structValidatorType = reflect.TypeOf((*StructValidator)(nil)).Elem()
+
+// Reporter collects the errors of a StructValidator, with field paths
+// relative to the struct being validated
+type Reporter struct {
+	w    *walker
+	val  reflect.Value
+	path fieldPath
+}
+
+// Context returns the context of the validation run
+func (r *Reporter) Context() context.Context {
+	return r.w.ctx
+}
+
+// Report records a failed rule of a field of the struct, field may be a
+// dotted path of Go names below the struct or "" for the struct itself
+func (r *Reporter) Report(field, tag string, err error) {
+	path, val := r.path, r.val
+	if field != "" {
+		for _, name := range strings.Split(field, ".") {
+			display := name
+			if val.Kind() == reflect.Struct {
+				if d, ok := r.w.v.planFor(val.Type()).display[name]; ok {
+					display = d
+				}
+				val = val.FieldByName(name)
+				for val.Kind() == reflect.Ptr && !val.IsNil() {
+					val = val.Elem()
+				}
+			} else {
+				val = reflect.Value{}
+			}
+			path = path.field(name, display)
+		}
+	}
+	if err == nil || r.w.done() || !r.w.filter.includes(path.name) {
+		return
+	}
+
+	var value any
+	if val.IsValid() && val.CanInterface() {
+		value = val.Interface()
+	}
+
+	r.w.report(ValidationError{
+		Field:       path.display,
+		StructField: path.name,
+		Tag:         tag,
+		Value:       value,
+		Err:         err,
+	})
+}
+
+// implementsHook reports whether a struct type or its pointer is a StructValidator
+func implementsHook(typ reflect.Type) bool {
+	return typ.Implements(structValidatorType) || reflect.PointerTo(typ).Implements(structValidatorType)
+}
+
+// callHook runs the StructValidator of a struct value at path
+func (w *walker) callHook(val reflect.Value, path fieldPath) {
+	if w.done() {
+		return
+	}
+
+	var hook StructValidator
+	if val.Type().Implements(structValidatorType) {
+		hook = val.Interface().(StructValidator)
+	} else {
+		// pointer receiver, validate an addressable copy when needed
+		ptr := reflect.New(val.Type())
+		if val.CanAddr() {
+			ptr = val.Addr()
+		} else {
+			ptr.Elem().Set(val)
+		}
+		hook = ptr.Interface().(StructValidator)
+	}
+
+	hook.ValidateWith(&Reporter{w: w, val: val, path: path})
+}
@@ -0,0 +1,114 @@
+package govalid
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/harrysan/govalid/rules"
+)
+
+// Option configures a Validator created with New
+type Option func(*Validator)
+
+// WithTagName sets the struct tag holding the validation rules (default "validate")
+func WithTagName(name string) Option {
+	return func(v *Validator) {
+		v.tagName = name
+	}
+}
+
+// WithConditionTagName sets the struct tag holding the conditional rules (default "validate_if")
+func WithConditionTagName(name string) Option {
+	return func(v *Validator) {
+		v.condTagName = name
+	}
+}
+
+// WithMessageTagName sets the struct tag holding custom error messages (default "error_message")
+func WithMessageTagName(name string) Option {
+	return func(v *Validator) {
+		v.msgTagName = name
+	}
+}
+
+// FieldNameFunc returns the name of a struct field in errors, "" keeps the Go name
+type FieldNameFunc func(field reflect.StructField) string
+
+// WithFieldNameFunc sets how fields are named in ValidationError.Field,
+// ValidationError.StructField always holds the Go names
+func WithFieldNameFunc(fn FieldNameFunc) Option {
+	return func(v *Validator) {
+		v.fieldName = fn
+	}
+}
+
+// FieldNameFromTag names fields after a tag like json or form, falling back
+// to the Go name when the tag is missing or "-"
+func FieldNameFromTag(tagName string) FieldNameFunc {
+	return func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get(tagName), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+}
+
+// WithRegexRules makes the Validator use the given regex registry instead of its own
+func WithRegexRules(r *rules.RegexRules) Option {
+	return func(v *Validator) {
+		v.regexRules = r
+	}
+}
+
+// WithFailFast stops validation at the first failed rule
+func WithFailFast() Option {
+	return WithMaxErrors(1)
+}
+
+// WithMaxErrors stops validation once n rules failed, 0 means no limit
+func WithMaxErrors(n int) Option {
+	return func(v *Validator) {
+		v.maxErrors = n
+	}
+}
+
+// WithUnexportedFields also validates the unexported fields of structs,
+// which are skipped by default
+func WithUnexportedFields() Option {
+	return func(v *Validator) {
+		v.unexported = true
+	}
+}
+
+// WithStrictTags rejects tags with unknown rules, empty rules or parameters
+// that do not parse, e.g. min=abc, with a TagSyntaxError instead of ignoring them
+func WithStrictTags() Option {
+	return func(v *Validator) {
+		v.strict = true
+	}
+}
+
+// WithMaxDepth stops validation with a LimitExceededError below n levels of
+// nested structs, elements and map entries, 0 means no limit
+func WithMaxDepth(n int) Option {
+	return func(v *Validator) {
+		v.maxDepth = n
+	}
+}
+
+// WithMaxNodes stops validation with a LimitExceededError once n fields,
+// elements and map entries were visited, 0 means no limit
+func WithMaxNodes(n int) Option {
+	return func(v *Validator) {
+		v.maxNodes = n
+	}
+}
+
+// WithMaxCollectionLen stops validation with a LimitExceededError at the
+// first walked slice, array or map with more than n items, 0 means no limit
+func WithMaxCollectionLen(n int) Option {
+	return func(v *Validator) {
+		v.maxCollectionLen = n
+	}
+}
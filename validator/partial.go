@@ -0,0 +1,127 @@
+package govalid
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldFilter selects the fields of a partial validation by dotted path
+type fieldFilter struct {
+	paths  map[string]bool
+	except bool
+}
+
+func newFieldFilter(fields []string, except bool) *fieldFilter {
+	f := &fieldFilter{paths: make(map[string]bool, len(fields)), except: except}
+	for _, field := range fields {
+		f.paths[field] = true
+	}
+	return f
+}
+
+// ValidatePartial validates only the given fields using the default Validator
+func ValidatePartial(s any, fields ...string) []ValidationError {
+	return defaultValidator.ValidatePartial(s, fields...)
+}
+
+// ValidateExcept validates every field but the given ones using the default Validator
+func ValidateExcept(s any, fields ...string) []ValidationError {
+	return defaultValidator.ValidateExcept(s, fields...)
+}
+
+// ValidatePartial validates only the given fields, addressed by dotted paths
+// such as "Address.City". Selecting a struct field selects all of its fields.
+func (v *Validator) ValidatePartial(s any, fields ...string) []ValidationError {
+	return v.validateFiltered(s, newFieldFilter(fields, false))
+}
+
+// ValidateExcept validates every field but the given ones, addressed by dotted
+// paths such as "Address.City". Excluding a struct field excludes all of its fields.
+func (v *Validator) ValidateExcept(s any, fields ...string) []ValidationError {
+	return v.validateFiltered(s, newFieldFilter(fields, true))
+}
+
+func (v *Validator) validateFiltered(s any, filter *fieldFilter) []ValidationError {
+	errs, err := v.validate(context.Background(), s, filter)
+	if err != nil {
+		return []ValidationError{usageError(err)}
+	}
+	return errs
+}
+
+// includes reports whether the rules of the field at path run
+func (f *fieldFilter) includes(path string) bool {
+	if f == nil {
+		return true
+	}
+	return f.listed(path) != f.except
+}
+
+// descends reports whether any field below path is validated
+func (f *fieldFilter) descends(path string) bool {
+	if f == nil {
+		return true
+	}
+	if f.except {
+		return !f.listed(path)
+	}
+	if f.listed(path) {
+		return true
+	}
+	for p := range f.paths {
+		if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// listed reports whether path or one of its parents is in the filter,
+// the parent of "Items[3]" being "Items"
+func (f *fieldFilter) listed(path string) bool {
+	for {
+		if f.paths[path] {
+			return true
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			return false
+		}
+		path = path[:i]
+	}
+}
+
+// join builds the dotted path of a field below prefix
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// fieldPath is the path of a field by Go names, used by filters, and by
+// display names, used in errors
+type fieldPath struct {
+	name    string
+	display string
+}
+
+// field returns the path of a struct field below p
+func (p fieldPath) field(name, display string) fieldPath {
+	return fieldPath{name: join(p.name, name), display: join(p.display, display)}
+}
+
+// index returns the path of the i-th element of the collection at p
+func (p fieldPath) index(i int) fieldPath {
+	s := "[" + strconv.Itoa(i) + "]"
+	return fieldPath{name: p.name + s, display: p.display + s}
+}
+
+// key returns the path of the entry of the map at p with the given key
+func (p fieldPath) key(key reflect.Value) fieldPath {
+	s := "[" + fmt.Sprint(key.Interface()) + "]"
+	return fieldPath{name: p.name + s, display: p.display + s}
+}
@@ -0,0 +1,503 @@
+package govalid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/harrysan/govalid/internal/tag"
+)
+
+// structPlan is the precompiled validation plan of a struct type
+type structPlan struct {
+	fields     []*fieldPlan
+	display    map[string]string // display names of the exported fields by Go name
+	hook       bool              // implements StructValidator
+	unexported bool              // has unexported fields to validate
+	err        error
+}
+
+// fieldPlan holds the parsed tags of a single struct field
+type fieldPlan struct {
+	index      int
+	name       string
+	typ        reflect.Type // type the rules apply to, without pointers
+	display    string       // name in errors, see WithFieldNameFunc
+	unexported bool
+	nested     bool // struct or pointer to struct, validated field by field
+	iface      bool // interface, planned per dynamic type from raw
+	raw        []*rule
+	rules      []*rule
+	dive       *fieldPlan // plan of the elements of a slice or an array
+	bail       bool
+	keys       *fieldPlan // plan of the keys of a map
+	values     *fieldPlan // plan of the values of a map
+	omit       string     // omitempty or omitnil
+	omitAt     int        // number of rules before omit, the others are skipped for omitted values
+	message    string
+	cond       *condition
+}
+
+// rule is a single parsed rule of a tag, e.g. min=3
+type rule struct {
+	tag    string
+	name   string
+	param  string
+	quoted bool
+	num    float64
+	re     *regexp.Regexp // inline pattern of a quoted regex= parameter
+	alias  string         // alias the rule comes from, see RegisterAlias
+	not    bool           // negated, passes when the rule fails
+	or     []*rule        // alternatives of an OR group, passing when one passes
+	ref    *fieldRef      // field compared with by the eqfield family
+	dep    *dependency    // fields the required_if family depends on
+	src    tag.Rule
+}
+
+// planFor returns the cached plan of a struct type, compiling it on first use
+func (v *Validator) planFor(typ reflect.Type) *structPlan {
+	if p, ok := v.plans.Load(typ); ok {
+		return p.(*structPlan)
+	}
+
+	p, _ := v.plans.LoadOrStore(typ, v.compileStruct(typ))
+	return p.(*structPlan)
+}
+
+// compileStruct parses the tags of every field of a struct type
+func (v *Validator) compileStruct(typ reflect.Type) *structPlan {
+	plan := &structPlan{hook: implementsHook(typ), display: map[string]string{}}
+
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		display := v.displayName(fieldType)
+		if fieldType.IsExported() || fieldType.Anonymous {
+			// embedded structs of unexported types hold promoted exported fields
+			plan.display[fieldType.Name] = display
+		} else if !v.unexported {
+			continue
+		}
+
+		tagValue := fieldType.Tag.Get(v.tagName)
+		fp, err := v.compileField(fieldType.Name, fieldType.Type, tagValue)
+		if err == nil {
+			err = resolveRefs(fp, typ)
+		}
+		if err != nil {
+			plan.err = newTagSyntaxError(typ.String(), fieldType.Name, tagValue, err)
+			return plan
+		}
+		fp.index = i
+		fp.unexported = !fieldType.IsExported()
+		fp.setNames(display, fieldType.Tag.Get(v.msgTagName))
+
+		if tagVIf := fieldType.Tag.Get(v.condTagName); tagVIf != "" {
+			cond, err := v.compileCondition(typ, fieldType, tagVIf)
+			if err != nil {
+				plan.err = newTagSyntaxError(typ.String(), fieldType.Name, tagVIf, err)
+				return plan
+			}
+			cond.plan.setNames(fp.display, fp.message)
+			fp.cond = cond
+		}
+
+		// fields without rules are only walked when they hold structs
+		if len(fp.rules) > 0 || fp.nested || fp.iface || fp.dive != nil || fp.keys != nil || fp.values != nil || fp.cond != nil {
+			plan.fields = append(plan.fields, fp)
+			plan.unexported = plan.unexported || fp.unexported
+		}
+	}
+
+	return plan
+}
+
+// compileField parses the validate tag of a field of the given type
+func (v *Validator) compileField(name string, typ reflect.Type, tagValue string) (*fieldPlan, error) {
+	var rules []*rule
+	if tagValue != "" {
+		parsed, err := tag.Parse(tagValue, ",")
+		if err != nil {
+			return nil, err
+		}
+		if rules, err = v.newRules(parsed); err != nil {
+			return nil, err
+		}
+	}
+	return v.compileRules(name, typ, rules)
+}
+
+// compileRules builds the plan of a field of the given type, the rules
+// after a dive and the keys= and values= lists make up the plans of its
+// elements, keys and values
+func (v *Validator) compileRules(name string, typ reflect.Type, rules []*rule) (*fieldPlan, error) {
+	fp := &fieldPlan{name: name}
+
+	// rules apply to the value pointers point to
+	coll := typ
+	for coll.Kind() == reflect.Ptr {
+		coll = coll.Elem()
+	}
+	fp.typ = coll
+
+	switch coll.Kind() {
+	case reflect.Struct:
+		fp.nested = true
+	case reflect.Interface:
+		// the dynamic type is only known while validating
+		fp.iface, fp.raw = true, rules
+		return fp, nil
+	}
+
+	for i, r := range rules {
+		switch r.name {
+		case "bail":
+			fp.bail = true
+			continue
+
+		case "omitempty", "omitnil":
+			if fp.omit == "" {
+				fp.omit, fp.omitAt = r.name, len(fp.rules)
+			}
+			continue
+
+		case "keys", "values":
+			if coll.Kind() != reflect.Map {
+				return nil, ruleError(r, r.name+" requires a map, got "+typ.String())
+			}
+
+			elem := coll.Key()
+			if r.name == "values" {
+				elem = coll.Elem()
+			}
+			parsed, err := tag.ParseList(r.src)
+			if err != nil {
+				return nil, err
+			}
+			list, err := v.newRules(parsed)
+			if err != nil {
+				return nil, err
+			}
+			for _, sub := range list {
+				if sub.alias == "" {
+					sub.alias = r.alias
+				}
+			}
+			plan, err := v.compileRules(name, elem, list)
+			if err != nil {
+				return nil, err
+			}
+
+			if r.name == "keys" {
+				fp.keys = plan
+			} else {
+				fp.values = plan
+			}
+			continue
+
+		case "dive":
+			// dive applies to the values of a map
+			if coll.Kind() == reflect.Map {
+				values, err := v.compileRules(name, coll.Elem(), rules[i+1:])
+				if err != nil {
+					return nil, err
+				}
+				fp.values = values
+				return fp, nil
+			}
+
+			if coll.Kind() != reflect.Slice && coll.Kind() != reflect.Array {
+				return nil, ruleError(r, "dive requires a slice, an array or a map, got "+typ.String())
+			}
+
+			dive, err := v.compileRules(name, coll.Elem(), rules[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			fp.dive = dive
+			return fp, nil
+		}
+
+		fp.rules = append(fp.rules, r)
+	}
+
+	return fp, nil
+}
+
+// active returns the rules of a field, only the ones before omitempty or
+// omitnil when its value is omitted
+func (fp *fieldPlan) active(omitted bool) []*rule {
+	if omitted && fp.omit != "" {
+		return fp.rules[:fp.omitAt]
+	}
+	return fp.rules
+}
+
+// dynKey identifies the plan of an interface field for a dynamic type
+type dynKey struct {
+	fp  *fieldPlan
+	typ reflect.Type
+}
+
+// dynamicPlan is a cached plan of an interface field or the error compiling it
+type dynamicPlan struct {
+	fp  *fieldPlan
+	err error
+}
+
+// dynamicPlanFor returns the plan of an interface field for the dynamic
+// type of its value, compiling it on first use
+func (v *Validator) dynamicPlanFor(fp *fieldPlan, typ reflect.Type) (*fieldPlan, error) {
+	key := dynKey{fp: fp, typ: typ}
+	if p, ok := v.dynamic.Load(key); ok {
+		return p.(*dynamicPlan).fp, p.(*dynamicPlan).err
+	}
+
+	p := &dynamicPlan{}
+	p.fp, p.err = v.compileRules(fp.name, typ, fp.raw)
+	if p.err != nil {
+		p.err = newTagSyntaxError("", fp.name, ruleTags(fp.raw), fmt.Errorf("%w (dynamic type %s of an interface)", p.err, typ))
+	} else {
+		p.fp.setNames(fp.display, fp.message)
+	}
+
+	cached, _ := v.dynamic.LoadOrStore(key, p)
+	return cached.(*dynamicPlan).fp, cached.(*dynamicPlan).err
+}
+
+// ruleTags joins rules back into a tag
+func ruleTags(rules []*rule) string {
+	tags := make([]string, 0, len(rules))
+	for _, r := range rules {
+		tags = append(tags, r.tag)
+	}
+	return strings.Join(tags, ",")
+}
+
+// setNames sets the display name and the error_message of a field and of
+// its elements, keys and values
+func (fp *fieldPlan) setNames(display, message string) {
+	if fp == nil {
+		return
+	}
+	fp.display, fp.message = display, message
+	fp.dive.setNames(display, message)
+	fp.keys.setNames(display, message)
+	fp.values.setNames(display, message)
+}
+
+// displayName returns the name of a field in errors
+func (v *Validator) displayName(field reflect.StructField) string {
+	if v.fieldName != nil {
+		if name := v.fieldName(field); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// newRules prepares parsed rules, expanding aliases and skipping empty
+// rules outside of strict mode
+func (v *Validator) newRules(parsed []tag.Rule) ([]*rule, error) {
+	return v.appendRules(make([]*rule, 0, len(parsed)), parsed, nil, nil)
+}
+
+// appendRules appends prepared rules to rules, use being the alias of the
+// tag the rules are expanded from and seen the aliases being expanded
+func (v *Validator) appendRules(rules []*rule, parsed []tag.Rule, use *tag.Rule, seen []string) ([]*rule, error) {
+	for _, t := range parsed {
+		if len(t.Or) > 0 {
+			r, err := v.newGroup(t, use, seen)
+			if err != nil {
+				return nil, aliasError(use, err)
+			}
+			rules = append(rules, r)
+			continue
+		}
+		if t.Name == "" && !v.strict {
+			continue
+		}
+
+		tags, isAlias := v.alias(t.Name)
+		if isAlias && t.Not {
+			// a negated alias stands for a single negated rule
+			t.Not = false
+			r, err := v.singleRule(t, use, seen, "negated alias")
+			if err != nil {
+				return nil, err
+			}
+			r.not, r.tag = !r.not, "!"+r.tag
+			rules = append(rules, r)
+			continue
+		}
+		if !isAlias {
+			if use != nil {
+				// errors point at the alias in the tag
+				t.Column = use.Column
+			}
+			r, err := v.newRule(t)
+			if err != nil {
+				return nil, aliasError(use, err)
+			}
+			if use != nil {
+				r.alias = use.Name
+			}
+			rules = append(rules, r)
+			continue
+		}
+
+		if t.Param != "" {
+			return nil, &tag.SyntaxError{Column: t.Column, Msg: fmt.Sprintf("alias %q takes no parameter", t.Name)}
+		}
+		if slices.Contains(seen, t.Name) {
+			return nil, &tag.SyntaxError{Column: use.Column, Msg: "alias cycle: " + strings.Join(append(seen, t.Name), " -> ")}
+		}
+
+		expanded, err := tag.Parse(tags, ",")
+		if err != nil {
+			return nil, err
+		}
+		outer := use
+		if outer == nil {
+			outer = &t
+		}
+		if rules, err = v.appendRules(rules, expanded, outer, append(seen, t.Name)); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// newGroup prepares the alternatives of an OR group
+func (v *Validator) newGroup(t tag.Rule, use *tag.Rule, seen []string) (*rule, error) {
+	group := &rule{tag: t.Tag, src: t}
+	if use != nil {
+		group.alias = use.Name
+	}
+
+	for _, alt := range t.Or {
+		if use != nil {
+			alt.Column = use.Column
+		}
+		r, err := v.singleRule(alt, use, seen, "alias in an OR group")
+		if err != nil {
+			return nil, err
+		}
+		if walksRule(r.name) {
+			return nil, ruleError(r, r.name+" cannot be used in an OR group")
+		}
+		group.or = append(group.or, r)
+	}
+	return group, nil
+}
+
+// singleRule prepares a rule that must not expand to several rules, what
+// naming it in errors
+func (v *Validator) singleRule(t tag.Rule, use *tag.Rule, seen []string, what string) (*rule, error) {
+	list, err := v.appendRules(nil, []tag.Rule{t}, use, seen)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) != 1 {
+		return nil, &tag.SyntaxError{Column: t.Column, Msg: fmt.Sprintf("%s %q must expand to a single rule", what, t.Name)}
+	}
+	return list[0], nil
+}
+
+// walksRule reports whether a rule shapes the walk of a field instead of
+// checking its value, such rules cannot be negated nor used in OR groups
+func walksRule(name string) bool {
+	switch name {
+	case "bail", "dive", "keys", "values", "omitempty", "omitnil":
+		return true
+	}
+	return false
+}
+
+// aliasError names the alias a rule comes from in its error
+func aliasError(use *tag.Rule, err error) error {
+	var syntaxErr *tag.SyntaxError
+	if use == nil || !errors.As(err, &syntaxErr) {
+		return err
+	}
+	return &tag.SyntaxError{Column: syntaxErr.Column, Msg: fmt.Sprintf("%s in alias %q", syntaxErr.Msg, use.Name)}
+}
+
+// newRule parses the parameter of a rule once, so validation never re-parses
+// tags. In strict mode unknown rules and bad parameters are errors.
+func (v *Validator) newRule(t tag.Rule) (*rule, error) {
+	r := &rule{tag: t.Tag, name: t.Name, param: t.Param, quoted: t.Quoted, not: t.Not, src: t}
+	if r.not && walksRule(r.name) {
+		return nil, ruleError(r, r.name+" cannot be negated")
+	}
+
+	var err error
+	switch r.name {
+	case "min", "max":
+		r.num, err = strconv.ParseFloat(r.param, 64)
+		if err != nil && v.strict {
+			return nil, ruleError(r, fmt.Sprintf("invalid parameter %q for %s, expected a number", r.param, r.name))
+		}
+	case "len", "minItems", "maxItems":
+		var n int
+		n, err = strconv.Atoi(r.param)
+		if err != nil && v.strict {
+			return nil, ruleError(r, fmt.Sprintf("invalid parameter %q for %s, expected an integer", r.param, r.name))
+		}
+		r.num = float64(n)
+	case "regex":
+		if r.quoted {
+			r.re, err = regexp.Compile(r.param)
+			if err != nil && v.strict {
+				return nil, ruleError(r, fmt.Sprintf("invalid regex pattern %q: %v", r.param, err))
+			}
+		} else if r.param == "" && v.strict {
+			return nil, ruleError(r, "regex requires the name of a regex rule or a quoted pattern")
+		}
+	}
+
+	if v.strict && !v.knownRule(r) {
+		if r.name == "" {
+			return nil, ruleError(r, "empty rule")
+		}
+		name := r.name
+		if r.name == "custom" {
+			name = r.param
+		}
+		return nil, ruleError(r, fmt.Sprintf("unknown rule %q", name))
+	}
+
+	return r, nil
+}
+
+// knownRule reports whether a rule is built in or registered
+func (v *Validator) knownRule(r *rule) bool {
+	if r.name == "custom" && r.param != "" {
+		_, ok := v.customRule(r.param)
+		return ok
+	}
+	if tag.Builtin(r.name) {
+		return true
+	}
+	_, ok := v.customRule(r.name)
+	return ok
+}
+
+// ruleError is a syntax error at the column of a rule
+func ruleError(r *rule, msg string) error {
+	return &tag.SyntaxError{Column: r.src.Column, Msg: msg}
+}
+
+// resetPlans drops the cached plans, they are compiled again on next use
+func (v *Validator) resetPlans() {
+	for _, m := range []*sync.Map{&v.plans, &v.vars, &v.dynamic} {
+		m.Range(func(key, _ any) bool {
+			m.Delete(key)
+			return true
+		})
+	}
+}
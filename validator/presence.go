@@ -0,0 +1,130 @@
+package govalid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/harrysan/govalid/internal/tag"
+	"github.com/harrysan/govalid/rules"
+)
+
+// presenceRules are the rules requiring or excluding a value depending on
+// the other fields of the struct
+var presenceRules = map[string]bool{
+	"required_if": true, "required_unless": true,
+	"required_with": true, "required_with_all": true,
+	"required_without": true, "required_without_all": true,
+	"excluded_if": true, "excluded_unless": true,
+}
+
+// dependency holds the fields a rule of the required_if family depends on,
+// the fields and values the if and unless variants compare, the fields the
+// with and without variants check the presence of
+type dependency struct {
+	cmps []*comparison
+	refs []*fieldRef
+}
+
+// resolveDependency resolves the fields of a rule of the required_if family
+// in the struct type holding its field. The if and unless variants take
+// pairs of a field and a value, required_if=Method card, the with and
+// without variants a list of fields, required_with=BankName IBAN. Values
+// holding spaces are quoted, required_if=Method 'wire transfer'.
+func resolveDependency(r *rule, parent reflect.Type) error {
+	params, err := tag.Fields(r.src)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		return ruleError(r, r.name+" requires the name of a field")
+	}
+
+	r.dep = &dependency{}
+	if strings.HasSuffix(r.name, "_if") || strings.HasSuffix(r.name, "_unless") {
+		if len(params)%2 != 0 {
+			return ruleError(r, r.name+" expects pairs of a field and a value")
+		}
+		for i := 0; i < len(params); i += 2 {
+			src := tag.Comparison{Field: params[i], Op: "=", Values: []string{params[i+1]}}
+			c, err := compileComparison(parent, src)
+			if err != nil {
+				return ruleError(r, err.Error())
+			}
+			r.dep.cmps = append(r.dep.cmps, c)
+		}
+		return nil
+	}
+
+	for _, name := range params {
+		path := strings.Split(name, ".")
+		_, index, err := fieldByPath(parent, path)
+		if err != nil {
+			return ruleError(r, err.Error())
+		}
+		r.dep.refs = append(r.dep.refs, &fieldRef{path: path, index: index})
+	}
+	return nil
+}
+
+// applies reports whether a rule of the required_if family applies to the
+// field of the struct being validated, i.e. its value is required or
+// excluded
+func (w *walker) applies(r *rule) bool {
+	switch r.name {
+	case "required_if", "excluded_if":
+		return w.allHold(r.dep.cmps)
+	case "required_unless", "excluded_unless":
+		return !w.allHold(r.dep.cmps)
+	case "required_with":
+		return w.present(r.dep.refs, true)
+	case "required_with_all":
+		return !w.present(r.dep.refs, false)
+	case "required_without":
+		return w.present(r.dep.refs, false)
+	case "required_without_all":
+		return !w.present(r.dep.refs, true)
+	}
+	return false
+}
+
+// allHold reports whether every comparison holds for the struct being validated
+func (w *walker) allHold(cmps []*comparison) bool {
+	for _, c := range cmps {
+		if !c.holds(w.parent) {
+			return false
+		}
+	}
+	return true
+}
+
+// present reports whether one of the fields is set, or when set is false
+// whether one of them is missing, nil or the zero value
+func (w *walker) present(refs []*fieldRef, set bool) bool {
+	for _, ref := range refs {
+		field, err := fieldAt(w.parent, ref)
+		if (err == nil && field.IsValid() && !isEmpty(field)) == set {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPresence applies a rule of the required_if family
+func (w *walker) applyPresence(value any, r *rule) error {
+	if r.dep == nil {
+		return fmt.Errorf(" %s only applies to the fields of a struct;", r.name)
+	}
+	if !w.applies(r) {
+		return nil
+	}
+
+	if strings.HasPrefix(r.name, "excluded") {
+		if value != nil && !isEmpty(reflect.ValueOf(value)) {
+			return errors.New(" must not be set;")
+		}
+		return nil
+	}
+	return rules.ValidateRuleRequired(value)
+}
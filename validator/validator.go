@@ -1,234 +1,646 @@
 package govalid
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"unsafe"
 
 	"github.com/harrysan/govalid/rules"
 )
 
 type ValidationError struct {
+	// Field is the path of the field by display names, see WithFieldNameFunc
 	Field string
-	Tag   string
+	// StructField is the path of the field by Go names, e.g. Address.ZipCode
+	StructField string
+	Tag         string
+	// Alias is the alias the failed rule Tag comes from, see RegisterAlias
+	Alias string
 	Value interface{}
 	Err   error
 }
 
 func (ve ValidationError) Error() string {
+	if ve.Alias != "" {
+		return fmt.Sprintf("Field '%s' failed validation '%s' of alias '%s' : %v", ve.Field, ve.Tag, ve.Alias, ve.Err)
+	}
 	return fmt.Sprintf("Field '%s' failed validation '%s' : %v", ve.Field, ve.Tag, ve.Err)
 }
 
-// ValidateStruct validate struct based on tag
+func (ve ValidationError) Unwrap() error {
+	return ve.Err
+}
+
+// Validator owns its own custom rule and regex registries, tag name and
+// settings, so independent instances never see each other's registrations
+type Validator struct {
+	tagName     string
+	condTagName string
+	msgTagName  string
+	fieldName   FieldNameFunc
+	customMu    sync.RWMutex
+	customRules map[string]CustomRuleCtx
+	aliases     map[string]string
+	regexRules  *rules.RegexRules
+	plans       sync.Map // reflect.Type => *structPlan
+	vars        sync.Map // varKey => *varPlan
+	dynamic     sync.Map // dynKey => *dynamicPlan
+	maxErrors   int
+	unexported  bool
+	strict      bool
+
+	maxDepth         int
+	maxNodes         int
+	maxCollectionLen int
+}
+
+// defaultValidator backs the package level functions and shares the
+// package level regex registry of the rules package
+var defaultValidator = New(WithRegexRules(rules.DefaultRegexRules()))
+
+// New creates a Validator with its own registries
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		tagName:     "validate",
+		condTagName: "validate_if",
+		msgTagName:  "error_message",
+		customRules: map[string]CustomRuleCtx{},
+		aliases:     map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.regexRules == nil {
+		v.regexRules = rules.NewRegexRules()
+	}
+	return v
+}
+
+// Default returns the Validator used by the package level functions
+func Default() *Validator {
+	return defaultValidator
+}
+
+// GetRegexRule retrieves a regex rule of this Validator by name
+func (v *Validator) GetRegexRule(name string) (string, error) {
+	return v.regexRules.Get(name)
+}
+
+// AddOrUpdateRegexRule adds or updates a regex rule of this Validator
+func (v *Validator) AddOrUpdateRegexRule(name, pattern string) {
+	v.regexRules.AddOrUpdate(name, pattern)
+}
+
+// DeleteRegexRule removes a regex rule from this Validator
+func (v *Validator) DeleteRegexRule(name string) error {
+	return v.regexRules.Delete(name)
+}
+
+// Validate validates a struct or a pointer to a struct using the default Validator
+func Validate(s any) error {
+	return defaultValidator.Validate(s)
+}
+
+// ValidateCtx is Validate with a context using the default Validator
+func ValidateCtx(ctx context.Context, s any) error {
+	return defaultValidator.ValidateCtx(ctx, s)
+}
+
+// ValidateStruct validate struct based on tag using the default Validator
 func ValidateStruct(s any) []ValidationError {
-	var errs []ValidationError
-	err_s := ""
+	return defaultValidator.ValidateStruct(s)
+}
+
+// ValidateStructCtx is ValidateStruct with a context using the default Validator
+func ValidateStructCtx(ctx context.Context, s any) []ValidationError {
+	return defaultValidator.ValidateStructCtx(ctx, s)
+}
+
+// Validate validates a struct or a pointer to a struct. Failed fields are
+// returned as ValidationErrors, bad input as *InvalidValidationError and
+// malformed tags as *TagSyntaxError.
+func (v *Validator) Validate(s any) error {
+	return v.ValidateCtx(context.Background(), s)
+}
+
+// ValidateCtx is Validate with a context that is passed to CustomRuleCtx rules.
+// Once the context is done the walk stops and ctx.Err() is part of the result.
+func (v *Validator) ValidateCtx(ctx context.Context, s any) error {
+	errs, err := v.validate(ctx, s, nil)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return ValidationErrors(errs)
+	}
+	return nil
+}
+
+// ValidateStruct validate struct based on tag. Bad input and malformed tags
+// are reported as a single ValidationError wrapping the usage error.
+func (v *Validator) ValidateStruct(s any) []ValidationError {
+	return v.ValidateStructCtx(context.Background(), s)
+}
+
+// ValidateStructCtx is ValidateStruct with a context that is passed to
+// CustomRuleCtx rules. Once the context is done the walk stops and a
+// ValidationError wrapping ctx.Err() ends the result.
+func (v *Validator) ValidateStructCtx(ctx context.Context, s any) []ValidationError {
+	errs, err := v.validate(ctx, s, nil)
+	if err != nil {
+		return []ValidationError{usageError(err)}
+	}
+	return errs
+}
 
+// validate accepts a struct or a pointer to a struct and runs its plan on
+// the fields selected by filter
+func (v *Validator) validate(ctx context.Context, s any, filter *fieldFilter) ([]ValidationError, error) {
 	val := reflect.ValueOf(s)
-	typ := reflect.TypeOf(s)
 
-	keyRules := []string{}
-	valueRules := []string{}
+	w := v.newWalker(ctx, filter)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		w.enter(val)
+		val = val.Elem()
+	}
 
 	if val.Kind() != reflect.Struct {
-		panic("Validate: input must be a struct")
+		var typ reflect.Type
+		if val.IsValid() {
+			typ = val.Type()
+		}
+		return nil, &InvalidValidationError{Type: typ}
 	}
 
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
-		typ = typ.Elem()
+	w.root = val
+	if err := w.validateStruct(val, v.planFor(val.Type()), fieldPath{}); err != nil {
+		return nil, err
+	}
+	return w.errs, nil
+}
+
+// usageError turns an error about bad usage into a ValidationError
+func usageError(err error) ValidationError {
+	ve := ValidationError{Err: err}
+
+	var tagErr *TagSyntaxError
+	if errors.As(err, &tagErr) {
+		ve.Field = tagErr.Field
+		ve.StructField = tagErr.Field
+		ve.Tag = tagErr.Tag
+	}
+	return ve
+}
+
+// walker holds the state of a single validation run
+type walker struct {
+	v         *Validator
+	ctx       context.Context
+	filter    *fieldFilter
+	errs      []ValidationError
+	maxErrors int
+	stopped   bool
+
+	depth    int
+	nodes    int
+	visiting []visit // pointers and maps being walked, to break cycles
+
+	root   reflect.Value // struct being validated, for the csfield rules
+	parent reflect.Value // struct of the field being validated, for the eqfield rules
+}
+
+// visit identifies a value behind a pointer or a map
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (v *Validator) newWalker(ctx context.Context, filter *fieldFilter) *walker {
+	return &walker{v: v, ctx: ctx, filter: filter, maxErrors: v.maxErrors}
+}
+
+// report records a failed rule
+func (w *walker) report(ve ValidationError) {
+	w.errs = append(w.errs, ve)
+}
+
+// done reports whether the run reached its error limit or was cancelled
+func (w *walker) done() bool {
+	return w.stopped || w.maxErrors > 0 && len(w.errs) >= w.maxErrors
+}
+
+// cancelled stops the run once its context is done, reporting ctx.Err()
+func (w *walker) cancelled() bool {
+	if w.stopped {
+		return true
+	}
+	if err := w.ctx.Err(); err != nil {
+		w.errs = append(w.errs, ValidationError{Err: err})
+		w.stopped = true
+	}
+	return w.stopped
+}
+
+// exceeded stops the run with a LimitExceededError at path
+func (w *walker) exceeded(path fieldPath, limit string, max int) {
+	w.errs = append(w.errs, ValidationError{
+		Field:       path.display,
+		StructField: path.name,
+		Err:         &LimitExceededError{Limit: limit, Max: max},
+	})
+	w.stopped = true
+}
+
+// enter marks a pointer or a map as being walked, it returns false when
+// the value is already being walked higher up, which is a cycle
+func (w *walker) enter(val reflect.Value) bool {
+	key := visit{ptr: val.Pointer(), typ: val.Type()}
+	for _, v := range w.visiting {
+		if v == key {
+			return false
+		}
+	}
+	w.visiting = append(w.visiting, key)
+	return true
+}
+
+// leave ends the walk of the value last marked with enter
+func (w *walker) leave() {
+	w.visiting = w.visiting[:len(w.visiting)-1]
+}
+
+// validateStruct runs a precompiled plan against a struct value, prefix
+// being the dotted path of the struct below the root
+func (w *walker) validateStruct(val reflect.Value, plan *structPlan, prefix fieldPath) error {
+	if plan.err != nil {
+		return plan.err
 	}
 
+	// unexported fields are read through the address of the struct
+	if plan.unexported && !val.CanAddr() {
+		addressable := reflect.New(val.Type()).Elem()
+		addressable.Set(val)
+		val = addressable
+	}
+
+	parent := w.parent
+	w.parent = val
+	defer func() { w.parent = parent }()
+
 	// Iterate field
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		field2 := val.Type().Field(i)
-		fieldType := typ.Field(i)
-		err_s = ""
-
-		// Tag "validate"
-		tag := fieldType.Tag.Get("validate")
-		errorMessage := fieldType.Tag.Get("error_message")
-
-		if tag != "" {
-			// Split tag
-			rules := strings.Split(tag, ",")
-			for _, rule := range rules {
-				err := applyRule(fieldType.Name, field.Interface(), rule)
-
-				// for Struct
-				if field.Kind() == reflect.Struct {
-					err_s = applyRuleStruct(field.Interface())
-				}
+	for _, fp := range plan.fields {
+		if w.done() || w.cancelled() {
+			return nil
+		}
 
-				// for Map
-				if fieldType.Type.Kind() == reflect.Map {
-					if strings.HasPrefix(rule, "keys=") {
-						keyRules = strings.Split(strings.TrimPrefix(rule, "keys="), ";")
-					} else if strings.HasPrefix(rule, "values=") {
-						valueRules = strings.Split(strings.TrimPrefix(rule, "values="), ";")
-					}
-				}
+		field := val.Field(fp.index)
+		if fp.unexported {
+			field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		}
+		path := prefix.field(fp.name, fp.display)
 
-				if err_s != "" {
-					err = fmt.Errorf(err_s)
-				}
+		if err := w.validateField(fp, field, nil, path); err != nil {
+			return err
+		}
 
-				if errorMessage != "" {
-					err = fmt.Errorf(errorMessage)
-				}
+		// Tag "validate If", its rules only apply when the condition holds
+		if fp.cond != nil && !w.done() && fp.cond.holds(val) {
+			if err := w.visitField(fp.cond.plan, field, nil, path); err != nil {
+				return err
+			}
+		}
+	}
 
-				if err != nil {
-					errs = append(errs, ValidationError{
-						Field: fieldType.Name,
-						Tag:   rule,
-						Value: field.Interface(),
-						Err:   err,
-					})
-				}
+	if plan.hook {
+		w.callHook(val, prefix)
+	}
+
+	return nil
+}
+
+// validateField applies the rules of a field plan to a value, comparing it
+// with other for the eqfield family of rules, and descends into nested
+// structs. Only the parts selected by the filter run, path being the path
+// of the field.
+func (w *walker) validateField(fp *fieldPlan, field reflect.Value, other any, path fieldPath) error {
+	if w.v.maxNodes > 0 && w.nodes >= w.v.maxNodes {
+		w.exceeded(path, "MaxNodes", w.v.maxNodes)
+		return nil
+	}
+	if w.v.maxDepth > 0 && w.depth >= w.v.maxDepth {
+		w.exceeded(path, "MaxDepth", w.v.maxDepth)
+		return nil
+	}
+
+	w.nodes++
+	w.depth++
+	err := w.visitField(fp, field, other, path)
+	w.depth--
+	return err
+}
+
+// visitField is validateField once the limits are checked
+func (w *walker) visitField(fp *fieldPlan, field reflect.Value, other any, path fieldPath) error {
+	included := w.filter.includes(path.name)
+	walks := fp.nested || fp.iface || fp.dive != nil || fp.keys != nil || fp.values != nil
+
+	// rules apply to the value behind pointers and interfaces
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			if included {
+				w.missing(fp, field, path)
 			}
+			return nil
+		}
 
-			if fieldType.Type.Kind() == reflect.Map {
-				for _, key := range field.MapKeys() {
-					mapValue := field.MapIndex(key).Interface()
-
-					for _, rule := range keyRules {
-						err := applyRule(fieldType.Name, key.Interface(), rule)
-						if err != nil {
-							errs = append(errs, ValidationError{
-								Field: fieldType.Name,
-								Tag:   rule,
-								Value: key.Interface(), // field.Interface(),
-								Err:   fmt.Errorf(key.String() + err.Error()),
-							})
-						}
-					}
-
-					for _, rule := range valueRules {
-						err := applyRule(fieldType.Name, mapValue, rule)
-						if err != nil {
-							errs = append(errs, ValidationError{
-								Field: fieldType.Name,
-								Tag:   rule,
-								Value: mapValue, // field.Interface(),
-								Err:   fmt.Errorf(field.MapIndex(key).String() + err.Error()),
-							})
-						}
-					}
-				}
+		if field.Kind() == reflect.Ptr && walks {
+			if !w.enter(field) {
+				return nil
 			}
+			defer w.leave()
 		}
+		field = field.Elem()
+	}
+
+	if fp.iface {
+		var err error
+		if fp, err = w.v.dynamicPlanFor(fp, field.Type()); err != nil {
+			return err
+		}
+	}
 
-		// Tag "validate If"
-		tagVIf := fieldType.Tag.Get("validate_if")
-		if tagVIf != "" {
-			parts := strings.SplitN(strings.TrimPrefix(tagVIf, "validate_if:"), ",", 2)
-			if len(parts) != 2 {
-				panic("Invalid validate_if format. Expected 'Field=Value,rule'")
+	// omitempty and omitnil skip the rules after them and the walk
+	omitted := fp.omit == "omitempty" && isEmpty(field) ||
+		fp.omit == "omitnil" && (field.Kind() == reflect.Slice || field.Kind() == reflect.Map) && field.IsNil()
+	list := fp.active(omitted)
+
+	if included && len(list) > 0 {
+		// min, max and email check every element of a slice
+		if field.Kind() == reflect.Slice && elementRules(list) && !w.withinLen(field, path) {
+			return nil
+		}
+
+		value := field.Interface()
+
+		for _, r := range list {
+			err := w.applyRule(fp.display, value, other, r)
+
+			if err != nil && fp.message != "" {
+				err = errors.New(fp.message)
 			}
-			condition := parts[0]
-			additionalRule := parts[1]
 
-			// Parse condition (e.g., IsActive=true)
-			condParts := strings.SplitN(condition, "=", 2)
-			if len(condParts) != 2 {
-				panic("Invalid condition format in validate_if. Expected 'Field=Value'")
+			if err != nil {
+				w.report(ValidationError{
+					Field:       path.display,
+					StructField: path.name,
+					Tag:         r.tag,
+					Alias:       r.alias,
+					Value:       value,
+					Err:         err,
+				})
+				if fp.bail || w.done() {
+					return nil
+				}
 			}
-			condField, condValue := condParts[0], condParts[1]
+		}
+	}
+
+	if omitted {
+		return nil
+	}
 
-			// Check condition field
-			condFieldValue := reflect.ValueOf(s).FieldByName(condField)
-			if !condFieldValue.IsValid() {
-				panic("Condition field '" + condField + "' not found")
+	// for Slice and Array elements after dive
+	if fp.dive != nil && w.filter.descends(path.name) {
+		if !w.withinLen(field, path) {
+			return nil
+		}
+		for i := 0; i < field.Len(); i++ {
+			if w.done() || w.cancelled() {
+				return nil
+			}
+			if err := w.validateField(fp.dive, field.Index(i), nil, path.index(i)); err != nil {
+				return err
 			}
+		}
+		return nil
+	}
+
+	// for Struct and pointer to Struct
+	if fp.nested && w.filter.descends(path.name) {
+		if plan := w.v.planFor(field.Type()); len(plan.fields) > 0 || plan.hook || plan.err != nil {
+			return w.validateStruct(field, plan, path)
+		}
+		return nil
+	}
+
+	// for Map keys and values
+	if (fp.keys != nil || fp.values != nil) && w.filter.descends(path.name) {
+		if !w.withinLen(field, path) || !w.enter(field) {
+			return nil
+		}
+		defer w.leave()
 
-			// Convert condition value to string for comparison
-			condFieldValueStr := fmt.Sprintf("%v", condFieldValue.Interface())
-			if condFieldValueStr == condValue {
-				// Apply additional validation rule if condition is met
-				rules.ValidateField(s, field2, additionalRule)
+		for _, key := range sortedKeys(field) {
+			if w.done() || w.cancelled() {
+				return nil
 			}
-			// Check 2nd condition (e.g., required)
-			if additionalRule != "" {
-				err := applyRule(fieldType.Name, field.Interface(), additionalRule)
-				if err != nil {
-					errs = append(errs, ValidationError{
-						Field: fieldType.Name,
-						Tag:   additionalRule,
-						Value: field.Interface(),
-						Err:   err,
-					})
+
+			keyPath := path.key(key)
+			if fp.keys != nil {
+				if err := w.validateField(fp.keys, key, nil, keyPath); err != nil {
+					return err
+				}
+			}
+			if fp.values != nil && !w.done() {
+				if err := w.validateField(fp.values, field.MapIndex(key), nil, keyPath); err != nil {
+					return err
 				}
 			}
 		}
 	}
 
-	return errs
+	return nil
+}
+
+// withinLen checks a walked collection against MaxCollectionLen
+func (w *walker) withinLen(field reflect.Value, path fieldPath) bool {
+	if w.v.maxCollectionLen > 0 && field.Len() > w.v.maxCollectionLen {
+		w.exceeded(path, "MaxCollectionLen", w.v.maxCollectionLen)
+		return false
+	}
+	return true
+}
+
+// elementRules reports whether one of the rules checks every element of a
+// slice it is applied to
+func elementRules(list []*rule) bool {
+	for _, r := range list {
+		if r.name == "min" || r.name == "max" || r.name == "email" || elementRules(r.or) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty reports whether a value is its zero value or an empty slice or map,
+// for omitempty
+func isEmpty(field reflect.Value) bool {
+	if field.Kind() == reflect.Slice || field.Kind() == reflect.Map {
+		return field.Len() == 0
+	}
+	return field.IsZero()
 }
 
-// applyRule => validate a field in struct
-func applyRuleStruct(value any) string {
-	errs := ""
-	err_r := ""
-	// for Struct Validation
-	val_item := reflect.ValueOf(value)
-	typ_item := reflect.TypeOf(value)
+// missing reports the required rule, or a rule of the required_if family
+// that applies, of a field holding a nil pointer or a nil interface, its
+// other rules do not apply to a missing value
+func (w *walker) missing(fp *fieldPlan, field reflect.Value, path fieldPath) {
+	list := fp.active(true)
+	if fp.iface {
+		list = fp.raw
+	}
 
-	for i := 0; i < val_item.NumField(); i++ {
-		field := typ_item.Field(i)
-		value := val_item.Field(i)
-		tag := field.Tag.Get("validate")
-		errs = ""
+	for _, r := range list {
+		if r.name == "dive" || r.name == "omitempty" || r.name == "omitnil" {
+			return
+		}
+		// a nil value passes !required and the excluded_if family
+		required := r.name == "required" ||
+			presenceRules[r.name] && !strings.HasPrefix(r.name, "excluded") && r.dep != nil && w.applies(r)
+		if !required || r.not {
+			continue
+		}
 
-		rules := strings.Split(tag, ",")
-		for _, rule := range rules {
-			err := applyRule(field.Name, value.Interface(), rule)
-			if err != nil {
-				errs = errs + field.Name + err.Error()
-			}
+		err := rules.ValidateRuleRequired(nil)
+		if fp.message != "" {
+			err = errors.New(fp.message)
 		}
+		w.report(ValidationError{
+			Field:       path.display,
+			StructField: path.name,
+			Tag:         r.tag,
+			Alias:       r.alias,
+			Value:       field.Interface(),
+			Err:         err,
+		})
+		return
+	}
+}
+
+// sortedKeys returns the keys of a map in a stable order, so errors are
+// reported in the same order on every run
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i].Interface(), keys[j].Interface()
+		if c, err := rules.Compare(a, b); err == nil {
+			return c < 0
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+	return keys
+}
 
-		if errs != "" {
-			err_r = err_r + errs + "|"
+// applyRule => validate a field, other is the value the eqfield family compares with
+func (w *walker) applyRule(fieldName string, value, other any, r *rule) error {
+	var err error
+	if r.or != nil {
+		err = w.applyAny(fieldName, value, other, r)
+	} else {
+		err = w.applyOne(fieldName, value, other, r)
+	}
+
+	if r.not {
+		if err != nil {
+			return nil
 		}
+		return fmt.Errorf(" must not pass %s;", strings.TrimPrefix(r.tag, "!"))
 	}
+	return err
+}
 
-	return err_r
+// applyAny applies the alternatives of an OR group, it fails with the errors
+// of every alternative when none passes
+func (w *walker) applyAny(fieldName string, value, other any, r *rule) error {
+	failed := &AlternativesError{}
+	for _, alt := range r.or {
+		err := w.applyRule(fieldName, value, other, alt)
+		if err == nil {
+			return nil
+		}
+		failed.Tags = append(failed.Tags, alt.tag)
+		failed.Errs = append(failed.Errs, err)
+	}
+	return failed
 }
 
-// applyRule => validate a field
-func applyRule(fieldName string, value any, rule string) error {
-	switch {
-	case rule == "required":
+// applyOne applies a single rule
+func (w *walker) applyOne(fieldName string, value, other any, r *rule) error {
+	v, ctx := w.v, w.ctx
+	if r.ref != nil {
+		return w.compareField(fieldName, value, r)
+	}
+
+	switch r.name {
+	case "required":
 		return rules.ValidateRuleRequired(value)
-	case strings.HasPrefix(rule, "min="):
-		min, _ := strconv.ParseFloat(strings.TrimPrefix(rule, "min="), 64)
-		return rules.ValidateRuleMin(value, min)
-	case strings.HasPrefix(rule, "max="):
-		max, _ := strconv.ParseFloat(strings.TrimPrefix(rule, "max="), 64)
-		return rules.ValidateRuleMax(value, max)
-	case rule == "email":
+	case "required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "required_without_all", "excluded_if", "excluded_unless":
+		return w.applyPresence(value, r)
+	case "min":
+		return rules.ValidateRuleMin(value, r.num)
+	case "max":
+		return rules.ValidateRuleMax(value, r.num)
+	case "len":
+		return rules.ValidateRuleLen(value, int(r.num))
+	case "minItems":
+		return rules.ValidateRuleMinItems(value, int(r.num))
+	case "maxItems":
+		return rules.ValidateRuleMaxItems(value, int(r.num))
+	case "email":
 		return rules.ValidateRuleEmail(value)
-	case rule == "isTrue" || rule == "isFalse":
-		return rules.ValidateRuleBool(value, rule)
-	case rule == "slice":
+	case "isTrue", "isFalse":
+		return rules.ValidateRuleBool(value, r.name)
+	case "slice":
 		return rules.ValidateRuleSlice(value)
-	case rule == "maps":
+	case "maps":
 		return rules.ValidateRuleMap(value)
-	case rule == "custom":
-		return applyCustomRule(rule, fieldName, value)
-	case strings.Contains(rule, "struct"):
+	case "custom":
+		if r.param == "" {
+			return v.applyCustomRule(ctx, r.name, fieldName, value)
+		}
+		return v.applyCustomRule(ctx, r.param, fieldName, value)
+	case "struct":
 		return rules.ValidateRuleStruct(value)
-	case strings.HasPrefix(rule, "regex="):
-		pt := strings.TrimPrefix(rule, "regex=")
-
-		pattern, err := rules.GetRegexRule(pt)
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		return rules.ValidateRuleCompare(value, other, r.name)
+	case "eqcsfield", "necsfield", "gtcsfield", "gtecsfield", "ltcsfield", "ltecsfield":
+		return fmt.Errorf(" %s only applies to the fields of a struct;", r.name)
+	case "regex":
+		if r.quoted {
+			if r.re == nil {
+				return fmt.Errorf(" invalid regex pattern for %s;", value)
+			}
+			return rules.ValidateRuleRegexp(value, r.re)
+		}
+		re, err := v.regexRules.Regexp(r.param)
+		if errors.Is(err, rules.ErrRegexRuleNotFound) {
+			return fmt.Errorf("regex rule %s not found for field %s", r.param, fieldName)
+		}
 		if err != nil {
-			return fmt.Errorf("regex rule %s not found for field %s", pt, fieldName)
+			return fmt.Errorf(" invalid regex pattern for %s;", value)
 		}
 
-		return rules.ValidateRuleRegex(value, pattern)
+		return rules.ValidateRuleRegexp(value, re)
+	}
+
+	if _, exists := v.customRule(r.name); exists {
+		return v.applyCustomRule(ctx, r.name, fieldName, value)
 	}
 
 	return nil
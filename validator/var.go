@@ -0,0 +1,71 @@
+package govalid
+
+import (
+	"context"
+	"reflect"
+)
+
+// varKey identifies a cached ValidateVar plan
+type varKey struct {
+	tag string
+	typ reflect.Type
+}
+
+// ValidateVar validates a standalone value against a tag using the default Validator
+func ValidateVar(value any, tag string) []ValidationError {
+	return defaultValidator.ValidateVar(value, tag)
+}
+
+// ValidateVarWithValue validates a value against another one using the default Validator
+func ValidateVarWithValue(value, other any, tag string) []ValidationError {
+	return defaultValidator.ValidateVarWithValue(value, other, tag)
+}
+
+// ValidateVar validates a standalone value against a tag, e.g. "required,min=3",
+// without wrapping it in a struct
+func (v *Validator) ValidateVar(value any, tag string) []ValidationError {
+	return v.ValidateVarWithValue(value, nil, tag)
+}
+
+// ValidateVarWithValue validates a value against a tag, comparing it with
+// other for the eqfield, nefield, gtfield, gtefield, ltfield and ltefield rules
+func (v *Validator) ValidateVarWithValue(value, other any, tag string) []ValidationError {
+	field := reflect.ValueOf(value)
+	if !field.IsValid() {
+		field = reflect.ValueOf(&value).Elem()
+	}
+
+	fp, err := v.varPlanFor(tag, field.Type())
+	if err != nil {
+		return []ValidationError{usageError(err)}
+	}
+
+	w := v.newWalker(context.Background(), nil)
+	if err := w.validateField(fp, field, other, fieldPath{}); err != nil {
+		return []ValidationError{usageError(err)}
+	}
+	return w.errs
+}
+
+// varPlan is a cached ValidateVar plan or the error compiling it
+type varPlan struct {
+	fp  *fieldPlan
+	err error
+}
+
+// varPlanFor returns the cached plan of a tag applied to a value of the given type
+func (v *Validator) varPlanFor(tag string, typ reflect.Type) (*fieldPlan, error) {
+	key := varKey{tag: tag, typ: typ}
+	if p, ok := v.vars.Load(key); ok {
+		return p.(*varPlan).fp, p.(*varPlan).err
+	}
+
+	p := &varPlan{}
+	p.fp, p.err = v.compileField("", typ, tag)
+	if p.err != nil {
+		p.err = newTagSyntaxError("", "", tag, p.err)
+	}
+
+	cached, _ := v.vars.LoadOrStore(key, p)
+	return cached.(*varPlan).fp, cached.(*varPlan).err
+}